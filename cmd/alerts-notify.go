@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var notifyOptions services.NotifyOptions
+
+var alertsNotifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Send alert changes since the last run to webhooks, Slack, Teams or Jira",
+	Long: `Compare the open alerts of a repository or organization with the previous run and send the
+new, reopened and resolved alerts to the notifiers of the config file (~/.gh-advanced-security.yaml):
+
+  notifiers:
+    - name: soc
+      type: webhook                  # JSON {"count": n, "events": [...]}
+      url: https://siem.example.com/hooks/ghas
+      headers: {Authorization: "Bearer $SIEM_TOKEN"}
+    - name: payments-team
+      type: slack                    # or teams
+      url: $PAYMENTS_SLACK_WEBHOOK
+      min_severity: critical
+      repositories: "^my-org/payments-"
+    - name: jira
+      type: jira                     # one issue per new or reopened alert
+      url: https://jira.example.com
+      project: SEC
+      issue_type: Bug
+      user: ghas-bot@example.com
+      token: $JIRA_TOKEN
+
+Every notifier accepts events (default: new, reopened), types, min_severity and repositories filters.
+The first run only records the open alerts, unless --notify-existing is set.`,
+	Example: `
+  gh advanced-security alerts notify my-org
+  gh advanced-security alerts notify my-org --notifier payments-team --type secret-scanning --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
+
+		if err := svc.NotifyAlertChanges(target, notifyOptions, flags.JSON); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	alertsRootCmd.AddCommand(alertsNotifyCmd)
+	alertsNotifyCmd.Flags().StringSliceVar(&notifyOptions.Types, "type", services.AlertTypes, "Alert types: "+strings.Join(services.AlertTypes, ", "))
+	alertsNotifyCmd.Flags().StringSliceVar(&notifyOptions.Notifiers, "notifier", nil, "Only these notifiers of the config file (default: all)")
+	alertsNotifyCmd.Flags().StringVar(&notifyOptions.StateFile, "state", "", "File recording the alerts between runs (default: in the user cache directory)")
+	alertsNotifyCmd.Flags().BoolVar(&notifyOptions.NotifyExisting, "notify-existing", false, "On the first run, notify every open alert")
+	alertsNotifyCmd.Flags().BoolVar(&notifyOptions.DryRun, "dry-run", false, "Print the changes without notifying nor recording them")
+}
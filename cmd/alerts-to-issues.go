@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	issueOptions  services.IssueOptions
+	issueGroupBy  string
+	issueNoAssign bool
+)
+
+var alertsRootCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Act on security alerts across alert types",
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "What do you want to do with the alerts?")
+	},
+}
+
+var alertsToIssuesCmd = &cobra.Command{
+	Use:   "to-issues",
+	Short: "Open GitHub issues for open alerts",
+	Long: `Open one issue per open alert, or per rule per repository with --group-by rule, in the affected
+repository or in a central --tracker repository.
+
+Issues are labeled with --label and assigned to the CODEOWNERS of the alert files. A hidden marker
+in the issue body records the alert, so re-runs skip the alerts that already have an issue (the first
+label is used to find them). --template is a Go text/template file receiving .Repository, .Type,
+.Rule, .Owners and .Alerts (each with .Number, .Severity, .Title, .Path, .Line and .URL).
+
+Secret scanning alerts have no severity: they are high, or critical while the secret is still valid.`,
+	Example: `
+  gh advanced-security alerts to-issues my-org --severity critical --dry-run
+  gh advanced-security alerts to-issues my-org --type code-scanning --group-by rule --repo-filter '^my-org/api-'
+  gh advanced-security alerts to-issues my-org --severity high --tracker my-org/security-tracker --label security,triage`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
+
+		switch issueGroupBy {
+		case "alert":
+		case "rule":
+			issueOptions.GroupByRule = true
+		default:
+			fmt.Printf("Invalid --group-by '%s' (expected alert or rule)\n", issueGroupBy)
+			os.Exit(1)
+		}
+		if issueOptions.Tracker != "" {
+			parseRepo(issueOptions.Tracker) // validates the owner/repo format
+		}
+		issueOptions.AssignCodeowners = !issueNoAssign
+
+		created, err := svc.CreateIssuesFromAlerts(target, issueOptions)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if !issueOptions.DryRun {
+			fmt.Printf("Done! %d issues opened.\n", created)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(alertsRootCmd)
+	alertsRootCmd.AddCommand(alertsToIssuesCmd)
+	alertsToIssuesCmd.Flags().StringSliceVar(&issueOptions.Types, "type", services.AlertTypes, "Alert types: "+strings.Join(services.AlertTypes, ", "))
+	alertsToIssuesCmd.Flags().StringVar(&issueOptions.MinSeverity, "severity", "", "Only alerts at or above this severity: "+strings.Join(services.AlertSeverities, ", "))
+	alertsToIssuesCmd.Flags().StringVar(&issueOptions.RepoFilter, "repo-filter", "", "Only repositories whose full name matches this regular expression")
+	alertsToIssuesCmd.Flags().StringVar(&issueGroupBy, "group-by", "alert", "One issue per 'alert' or per 'rule' per repository")
+	alertsToIssuesCmd.Flags().StringVar(&issueOptions.Tracker, "tracker", "", "Open every issue in this repository (owner/repo) instead of the affected one")
+	alertsToIssuesCmd.Flags().StringVar(&issueOptions.TemplateFile, "template", "", "Go template file for the issue body")
+	alertsToIssuesCmd.Flags().StringSliceVar(&issueOptions.Labels, "label", []string{"security"}, "Issue labels (the first one is used to find existing issues)")
+	alertsToIssuesCmd.Flags().BoolVar(&issueNoAssign, "no-assign", false, "Don't assign the CODEOWNERS of the alert files")
+	alertsToIssuesCmd.Flags().BoolVar(&issueOptions.DryRun, "dry-run", false, "Show the issues that would be opened")
+}
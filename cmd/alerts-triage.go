@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	triageTypes []string
+	triageState string
+)
+
+var alertsTriageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Triage alerts in a full-screen view",
+	Long: `Browse the alerts of a repository or organization in a full-screen view: the alert list on the left,
+the details of the selected alert on the right, and a filter bar matching every word typed against the
+repository, type, state, severity, rule, title and path of the alerts.
+
+Keys:
+  ↑/↓ or j/k, PgUp/PgDn, Home/End   move in the list
+  /                                 edit the filter (Enter keeps it, Esc clears it)
+  d                                 dismiss the alert (resolve, for secrets): pick a reason, then comment
+  r                                 reopen a dismissed or resolved alert
+  o                                 open the alert in the browser
+  R                                 reload the alerts
+  Enter                             show the detail (on narrow terminals)
+  q or Ctrl+C                       quit
+
+Dismissed alerts stay listed until the next reload, so a dismissal can be undone with r.`,
+	Example: `
+  gh advanced-security alerts triage my-org
+  gh advanced-security alerts triage my-org/my-repo --type code-scanning --state dismissed`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
+
+		if err := svc.Triage(target, triageTypes, triageState); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	alertsRootCmd.AddCommand(alertsTriageCmd)
+	alertsTriageCmd.Flags().StringSliceVar(&triageTypes, "type", services.AlertTypes, "Alert types: "+strings.Join(services.AlertTypes, ", "))
+	alertsTriageCmd.Flags().StringVar(&triageState, "state", "open", "Alert state (open, dismissed, resolved, fixed...), empty for all")
+}
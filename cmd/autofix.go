@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	autofixBranch string
+	autofixOpenPR bool
+)
+
+var autofixCmd = &cobra.Command{
+	Use:   "autofix",
+	Short: "Copilot Autofix for Code Scanning alerts",
+	Long:  `Request, review and commit the fixes suggested by Copilot Autofix for Code Scanning alerts.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "Choose an autofix action:")
+	},
+}
+
+var autofixRequestCmd = &cobra.Command{
+	Use:     "request",
+	Short:   "Request autofixes for one or more alerts",
+	Example: `gh advanced-security autofix request owner/repo 12 15 21`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+		numbers := parseNumbers(args, 1, "Which alert numbers?")
+
+		if err := svc.AutofixStatuses(owner, repo, numbers, true, flags.JSON); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var autofixStatusCmd = &cobra.Command{
+	Use:     "status",
+	Short:   "Check the autofix status of one or more alerts",
+	Example: `gh advanced-security autofix status owner/repo 12 15 21`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+		numbers := parseNumbers(args, 1, "Which alert numbers?")
+
+		if err := svc.AutofixStatuses(owner, repo, numbers, false, flags.JSON); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var autofixShowCmd = &cobra.Command{
+	Use:     "show",
+	Short:   "Show the suggested fix of an alert",
+	Example: `gh advanced-security autofix show owner/repo 12`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+		number := parseNumber(args, 1, "Which alert number?")
+
+		if err := svc.ShowAutofix(owner, repo, number, flags.JSON); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var autofixCommitCmd = &cobra.Command{
+	Use:   "commit",
+	Short: "Commit suggested fixes to a new branch and optionally open a pull request",
+	Long: `Create --branch from the branch the first alert was found on, commit the suggested fix of each
+alert to it and print the resulting diff. With --pr a pull request is opened for review.`,
+	Example: `
+  gh advanced-security autofix commit owner/repo 12 --branch autofix/alert-12
+  gh advanced-security autofix commit owner/repo 12 15 21 --branch autofix/sql-injection --pr`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+		numbers := parseNumbers(args, 1, "Which alert numbers?")
+
+		if autofixBranch == "" {
+			autofixBranch = fmt.Sprintf("autofix/alert-%d", numbers[0])
+		}
+
+		if err := svc.CommitAutofixes(owner, repo, numbers, autofixBranch, autofixOpenPR); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(autofixCmd)
+	autofixCmd.AddCommand(autofixRequestCmd)
+	autofixCmd.AddCommand(autofixStatusCmd)
+	autofixCmd.AddCommand(autofixShowCmd)
+	autofixCmd.AddCommand(autofixCommitCmd)
+	autofixCommitCmd.Flags().StringVarP(&autofixBranch, "branch", "b", "", "Branch to create for the fixes (default: autofix/alert-<number>)")
+	autofixCommitCmd.Flags().BoolVar(&autofixOpenPR, "pr", false, "Open a pull request from the branch")
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var bypassFilter services.BypassRequestFilter
+var bypassReviewComment string
+
+var listBypassRequestsCmd = &cobra.Command{
+	Use:     "bypass-requests",
+	Aliases: []string{"br"},
+	Short:   "List delegated Push Protection bypass requests",
+	Long:    `List delegated bypass requests for Secret Scanning Push Protection for a repository or across an organization.`,
+	Example: `
+  # Pending requests across an organization
+  gh advanced-security list bypass-requests my-org
+
+  # Filter by requester, secret type and ruleset
+  gh advanced-security list bypass-requests my-org --requester octocat --secret-type github_personal_access_token
+  gh advanced-security list bypass-requests my-org --ruleset "Block secrets"
+
+  # Every request (any status) for a single repo
+  gh advanced-security list bypass-requests owner/repo --status all`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "For which org or repo do you want to list bypass requests?")
+
+		owner, repo := target, ""
+		if strings.Contains(target, "/") {
+			owner, repo = parseRepo(target)
+		}
+
+		err := svc.ListBypassRequests(owner, repo, bypassFilter, flags.JSON, flags.PageSize, flags.All)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var approveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Approve pending requests",
+	Long:  `Approve pending requests such as delegated Push Protection bypass requests.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "What do you want to approve?")
+	},
+}
+
+var denyCmd = &cobra.Command{
+	Use:   "deny",
+	Short: "Deny pending requests",
+	Long:  `Deny pending requests such as delegated Push Protection bypass requests.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "What do you want to deny?")
+	},
+}
+
+var approveBypassRequestCmd = &cobra.Command{
+	Use:     "bypass-request",
+	Aliases: []string{"br"},
+	Short:   "Approve a Push Protection bypass request",
+	Example: `gh advanced-security approve bypass-request owner/repo 42 --comment "Test fixture, not a real token"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		reviewBypassRequest(cmd, args, "approve")
+	},
+}
+
+var denyBypassRequestCmd = &cobra.Command{
+	Use:     "bypass-request",
+	Aliases: []string{"br"},
+	Short:   "Deny a Push Protection bypass request",
+	Example: `gh advanced-security deny bypass-request owner/repo 42 --comment "Rotate the key and remove it from history"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		reviewBypassRequest(cmd, args, "deny")
+	},
+}
+
+// Shared logic for approving/denying a bypass request
+func reviewBypassRequest(cmd *cobra.Command, args []string, status string) {
+	svc := services.GetAlertServices()
+
+	target, _ := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+	owner, repo := parseRepo(target)
+	number := parseNumber(args, 1, "Which bypass request number?")
+
+	comment := bypassReviewComment
+	if comment == "" {
+		response, err := prompt.Input("Reviewer comment", "")
+		if err != nil {
+			fmt.Printf("Unable to read input: %v\n", err)
+			os.Exit(1)
+		}
+		comment = response
+	}
+
+	fmt.Printf("Sending '%s' for bypass request #%d on %s/%s...\n", status, number, owner, repo)
+	if err := svc.ReviewBypassRequest(owner, repo, number, status, comment); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println("Success!")
+}
+
+func init() {
+	listBypassRequestsCmd.Flags().StringVar(&bypassFilter.Requester, "requester", "", "Only requests made by this user")
+	listBypassRequestsCmd.Flags().StringVar(&bypassFilter.SecretType, "secret-type", "", "Only requests for this secret type")
+	listBypassRequestsCmd.Flags().StringVar(&bypassFilter.Ruleset, "ruleset", "", "Only requests blocked by this ruleset (name or id)")
+	listBypassRequestsCmd.Flags().StringVar(&bypassFilter.Status, "status", "open", "Request status: open, approved, denied, completed, cancelled, expired or all")
+
+	approveBypassRequestCmd.Flags().StringVarP(&bypassReviewComment, "comment", "c", "", "Reviewer comment")
+	denyBypassRequestCmd.Flags().StringVarP(&bypassReviewComment, "comment", "c", "", "Reviewer comment")
+
+	listCmd.AddCommand(listBypassRequestsCmd)
+	rootCmd.AddCommand(approveCmd)
+	rootCmd.AddCommand(denyCmd)
+	approveCmd.AddCommand(approveBypassRequestCmd)
+	denyCmd.AddCommand(denyBypassRequestCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	customLink          string
+	disableCustomLink   bool
+	bypassConfiguration string
+	bypassReviewerTeams []string
+	bypassReviewerRoles []string
+)
+
+var configureCmd = &cobra.Command{
+	Use:     "configure",
+	Aliases: []string{"config"},
+	Short:   "Configure security feature settings",
+	Long:    `Configure organization level settings of security features, like the Push Protection custom link and bypass reviewers.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "What do you want to configure?")
+	},
+}
+
+var configurePushProtectionCmd = &cobra.Command{
+	Use:     "push-protection",
+	Aliases: []string{"pp"},
+	Short:   "Configure Push Protection custom link and delegated bypass",
+	Long: `Configure Push Protection for an organization:
+	- Custom link shown to developers when a push is blocked
+	- Delegated bypass reviewers (teams and/or roles) of a code security configuration`,
+	Example: `
+  # Point blocked developers to the internal guidance page
+  gh advanced-security configure push-protection my-org --custom-link https://wiki.example.com/secrets
+
+  # Turn the custom link off
+  gh advanced-security configure push-protection my-org --disable-custom-link
+
+  # Security champions team and a custom role review bypass requests
+  gh advanced-security configure push-protection my-org --configuration "Default" --reviewer-team security-champions --reviewer-role "Security Reviewer"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetEnforcerServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetOrg, "For which org do you want to configure Push Protection?")
+		if strings.Contains(target, "/") {
+			fmt.Println("This setting can only be applied on organizations.")
+			os.Exit(1)
+		}
+
+		linkChanged := cmd.Flags().Changed("custom-link") || disableCustomLink
+		reviewersChanged := cmd.Flags().Changed("reviewer-team") || cmd.Flags().Changed("reviewer-role")
+		if !linkChanged && !reviewersChanged {
+			fmt.Println("Nothing to configure. Use --custom-link, --disable-custom-link, --reviewer-team or --reviewer-role.")
+			os.Exit(1)
+		}
+
+		if linkChanged && !disableCustomLink && strings.TrimSpace(customLink) == "" {
+			fmt.Println("Error: --custom-link needs a URL (use --disable-custom-link to turn the link off)")
+			os.Exit(1)
+		}
+
+		if linkChanged {
+			fmt.Printf("Updating Push Protection custom link for '%s'...\n", target)
+			if err := svc.SetPushProtectionCustomLink(target, customLink, !disableCustomLink); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Println("- Custom link: Updated.")
+		}
+
+		if reviewersChanged {
+			if bypassConfiguration == "" {
+				fmt.Println("Error: --configuration is required to set bypass reviewers")
+				os.Exit(1)
+			}
+			fmt.Printf("Updating delegated bypass reviewers of configuration '%s'...\n", bypassConfiguration)
+			if err := svc.SetDelegatedBypassReviewers(target, bypassConfiguration, bypassReviewerTeams, bypassReviewerRoles); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Println("- Delegated bypass reviewers: Updated.")
+		}
+	},
+}
+
+func init() {
+	configurePushProtectionCmd.Flags().StringVar(&customLink, "custom-link", "", "URL shown to developers blocked by Push Protection")
+	configurePushProtectionCmd.Flags().BoolVar(&disableCustomLink, "disable-custom-link", false, "Disable the Push Protection custom link")
+	configurePushProtectionCmd.Flags().StringVar(&bypassConfiguration, "configuration", "", "Code security configuration (name or ID) holding the delegated bypass settings")
+	configurePushProtectionCmd.Flags().StringSliceVar(&bypassReviewerTeams, "reviewer-team", nil, "Team slug (or ID) allowed to review bypass requests (repeatable)")
+	configurePushProtectionCmd.Flags().StringSliceVar(&bypassReviewerRoles, "reviewer-role", nil, "Organization role name (or ID) allowed to review bypass requests (repeatable)")
+
+	rootCmd.AddCommand(configureCmd)
+	configureCmd.AddCommand(configurePushProtectionCmd)
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	patternsFile   string
+	testPattern    model.CustomPattern
+	expectNoMatch  bool
+	expectMatching bool
+)
+
+var listCustomPatternsCmd = &cobra.Command{
+	Use:     "custom-patterns",
+	Aliases: []string{"cp", "patterns"},
+	Short:   "List Secret Scanning custom patterns",
+	Long: `List the Secret Scanning custom patterns of an organization with their Push Protection setting and alert statistics.
+
+For a repository, list the patterns of its organization with the alerts they raised in the repository.
+The API doesn't list repository or enterprise patterns: the other non-provider secret types found in the
+alerts of the repository are listed with an unknown source.`,
+	Example: `
+  gh advanced-security list custom-patterns my-org
+  gh advanced-security list custom-patterns my-org/my-repo`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetSecretPatternServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "For which org or repo do you want to list custom patterns?")
+
+		var err error
+		if strings.Contains(target, "/") {
+			owner, repo := parseRepo(target)
+			err = svc.ListRepositoryCustomPatterns(owner, repo, flags.JSON)
+		} else {
+			err = svc.ListCustomPatterns(target, flags.JSON)
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Test security configurations locally",
+	Long:  `Test security configurations, like Secret Scanning custom patterns, locally before publishing them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "What do you want to test?")
+	},
+}
+
+var testCustomPatternsCmd = &cobra.Command{
+	Use:     "custom-patterns",
+	Aliases: []string{"cp", "patterns"},
+	Short:   "Test Secret Scanning custom patterns against sample files",
+	Long: `Run Secret Scanning custom patterns locally against sample files or directories and report every match.
+
+Patterns are given with flags (single pattern) or a YAML file (--file) like:
+
+  patterns:
+    - name: Internal token
+      secret_format: 'itk_[a-z0-9]{32}'
+      before_secret: '\A|[^0-9A-Za-z]'
+      after_secret: '\z|[^0-9A-Za-z]'
+      additional_match: ['[0-9]']
+      additional_not_match: ['^itk_0+$']
+
+Empty before/after delimiters default to the GitHub defaults.`,
+	Example: `
+  gh advanced-security test custom-patterns ./samples --file patterns.yaml
+  gh advanced-security test custom-patterns fixtures/config.env --name "Internal token" --secret-format 'itk_[a-z0-9]{32}'`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetSecretPatternServices()
+
+		paths := args
+		flags := services.GetGlobalFlags()
+		if len(paths) == 0 {
+			var target string
+			target, flags = services.GetTarget(cmd, args, services.TargetOther, "Which file or directory do you want to scan?")
+			paths = []string{target}
+		}
+
+		var patterns []model.CustomPattern
+		if patternsFile != "" {
+			loaded, err := svc.LoadPatterns(patternsFile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			patterns = loaded
+		}
+		if testPattern.SecretFormat != "" {
+			if testPattern.Name == "" {
+				testPattern.Name = "pattern"
+			}
+			patterns = append(patterns, testPattern)
+		}
+		if len(patterns) == 0 {
+			fmt.Println("Error: use --file or --secret-format to define at least one pattern")
+			os.Exit(1)
+		}
+
+		count, err := svc.TestPatterns(patterns, paths, flags.JSON)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if (expectNoMatch && count > 0) || (expectMatching && count == 0) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	testCustomPatternsCmd.Flags().StringVarP(&patternsFile, "file", "f", "", "YAML/JSON file with pattern definitions")
+	testCustomPatternsCmd.Flags().StringVar(&testPattern.Name, "name", "", "Pattern name")
+	testCustomPatternsCmd.Flags().StringVar(&testPattern.SecretFormat, "secret-format", "", "Secret format regular expression")
+	testCustomPatternsCmd.Flags().StringVar(&testPattern.BeforeSecret, "before-secret", "", "Before secret regular expression (default: GitHub default)")
+	testCustomPatternsCmd.Flags().StringVar(&testPattern.AfterSecret, "after-secret", "", "After secret regular expression (default: GitHub default)")
+	testCustomPatternsCmd.Flags().StringArrayVar(&testPattern.AdditionalMatch, "must-match", nil, "Additional regular expression the secret must match (repeatable)")
+	testCustomPatternsCmd.Flags().StringArrayVar(&testPattern.AdditionalNotMatch, "must-not-match", nil, "Additional regular expression the secret must not match (repeatable)")
+	testCustomPatternsCmd.Flags().BoolVar(&expectNoMatch, "expect-none", false, "Exit with status 1 if any match is found (negative samples)")
+	testCustomPatternsCmd.Flags().BoolVar(&expectMatching, "expect-match", false, "Exit with status 1 if nothing matches (positive samples)")
+
+	listCmd.AddCommand(listCustomPatternsCmd)
+	rootCmd.AddCommand(testCmd)
+	testCmd.AddCommand(testCustomPatternsCmd)
+}
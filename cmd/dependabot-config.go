@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dependabotConfigInterval string
+	dependabotConfigOutput   string
+)
+
+var dependabotRootCmd = &cobra.Command{
+	Use:   "dependabot",
+	Short: "Manage Dependabot",
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "Choose a Dependabot action:")
+	},
+}
+
+var dependabotConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Audit or generate Dependabot version updates configuration (dependabot.yml)",
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "Choose a configuration action:")
+	},
+}
+
+var dependabotConfigAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit the dependabot.yml of a repository or organization",
+	Long: `Fetch .github/dependabot.yml from a repository, or from every non archived repository of an organization, and:
+	- Validate it against the configuration schema (version 2)
+	- Report ecosystems found in the dependency graph without an update entry
+
+Status is one of: ok, missing (no dependabot.yml), invalid, incomplete (ecosystems not covered)
+or unknown (the configuration or the dependency graph couldn't be read).
+Exits with status 1 when a repository is not ok, so it can be used as a CI gate.`,
+	Example: `
+  gh advanced-security dependabot config audit my-org
+  gh advanced-security dependabot config audit owner/repo --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
+
+		failing, err := svc.AuditDependabotConfigs(target, flags.JSON)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if failing > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var dependabotConfigGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Propose a dependabot.yml from the manifests in the dependency graph",
+	Example: `
+  gh advanced-security dependabot config generate owner/repo
+  gh advanced-security dependabot config generate owner/repo --interval daily -o .github/dependabot.yml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+
+		if err := svc.GenerateDependabotConfig(owner, repo, dependabotConfigInterval, dependabotConfigOutput); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dependabotRootCmd)
+	dependabotRootCmd.AddCommand(dependabotConfigCmd)
+	dependabotConfigCmd.AddCommand(dependabotConfigAuditCmd)
+	dependabotConfigCmd.AddCommand(dependabotConfigGenerateCmd)
+	dependabotConfigGenerateCmd.Flags().StringVar(&dependabotConfigInterval, "interval", "weekly", "Update schedule: daily, weekly, monthly, quarterly, semiannually or yearly")
+	dependabotConfigGenerateCmd.Flags().StringVarP(&dependabotConfigOutput, "output", "o", "", "Write the configuration to this file instead of the terminal")
+}
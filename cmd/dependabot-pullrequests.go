@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prsIncludeVersionUpdates bool
+	prsMerge                 bool
+	prsMergePolicy           services.DependabotMergePolicy
+	prsSkipConfirm           bool
+)
+
+var dependabotPullRequestsCmd = &cobra.Command{
+	Use:     "prs",
+	Aliases: []string{"pull-requests"},
+	Short:   "List open Dependabot security update pull requests",
+	Long: `List the open Dependabot pull requests of a repository or organization with the alerts they fix,
+their age, CI status (checks of the head commit) and mergeability.
+
+With --merge, the pull requests matching the merge policy are merged:
+	- Not a draft, no conflicts and every check passing
+	- An update no larger than --max-level (patch by default; grouped updates never match,
+	  and before 1.0 a minor update counts as major)`,
+	Example: `
+  # Oldest security update pull requests first
+  gh advanced-security dependabot prs my-org
+
+  # Merge the passing patch-level updates
+  gh advanced-security dependabot prs my-org --merge --max-level patch --merge-method squash`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
+
+		if prsMerge {
+			if err := prsMergePolicy.Validate(); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+
+		prs, err := svc.ListDependabotPullRequests(target, prsIncludeVersionUpdates)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if !prsMerge {
+			if err := svc.PrintDependabotPullRequests(prs, flags.JSON); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		// === Merge Mode ===
+		eligible := []model.DependabotPullRequest{}
+		for _, pr := range prs {
+			if prsMergePolicy.Eligible(pr) {
+				eligible = append(eligible, pr)
+			}
+		}
+		if len(eligible) == 0 {
+			fmt.Printf("None of the %d pull requests match the merge policy.\n", len(prs))
+			return
+		}
+
+		if err := svc.PrintDependabotPullRequests(eligible, false); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if !prsSkipConfirm {
+			ok, err := prompt.Confirm(fmt.Sprintf("Merge these %d pull requests (%s)?", len(eligible), prsMergePolicy.MergeMethod), false)
+			if err != nil || !ok {
+				fmt.Println("Aborted.")
+				os.Exit(0)
+			}
+		}
+
+		merged := svc.MergeDependabotPullRequests(eligible, prsMergePolicy.MergeMethod)
+		fmt.Printf("Done! %d of %d pull requests merged.\n", merged, len(eligible))
+		if merged != len(eligible) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	dependabotRootCmd.AddCommand(dependabotPullRequestsCmd)
+	dependabotPullRequestsCmd.Flags().BoolVar(&prsIncludeVersionUpdates, "include-version-updates", false, "Also list version update pull requests (not linked to alerts)")
+	dependabotPullRequestsCmd.Flags().BoolVar(&prsMerge, "merge", false, "Merge the pull requests matching the merge policy")
+	dependabotPullRequestsCmd.Flags().StringVar(&prsMergePolicy.MaxLevel, "max-level", "patch", "Merge policy: largest update to merge (patch, minor, major)")
+	dependabotPullRequestsCmd.Flags().StringVar(&prsMergePolicy.MergeMethod, "merge-method", "squash", "Merge method: merge, squash or rebase")
+	dependabotPullRequestsCmd.Flags().BoolVarP(&prsSkipConfirm, "yes", "y", false, "Merge: don't ask for confirmation")
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dismissReason  string
+	dismissComment string
+	dismissFilter  services.DependabotAlertFilter
+	skipConfirm    bool
+)
+
+var dismissCmd = &cobra.Command{
+	Use:   "dismiss",
+	Short: "Dismiss security alerts",
+	Long:  `Dismiss security alerts, one at a time or in bulk.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "Which type of alert do you want to dismiss?")
+	},
+}
+
+var reopenCmd = &cobra.Command{
+	Use:   "reopen",
+	Short: "Reopen dismissed security alerts",
+	Run: func(cmd *cobra.Command, args []string) {
+		services.ChooseSubCommand(cmd.Commands(), args, "Which type of alert do you want to reopen?")
+	},
+}
+
+var dismissDependabotCmd = &cobra.Command{
+	Use:     "dependabot",
+	Aliases: []string{"dep"},
+	Short:   "Dismiss Dependabot alerts",
+	Long: `Dismiss a single Dependabot alert, or every open alert matching an advisory (GHSA/CVE),
+package or manifest path in a repository or across an organization.
+
+Reasons: fix_started, inaccurate, no_bandwidth, not_used, tolerable_risk`,
+	Example: `
+  # Single alert
+  gh advanced-security dismiss dependabot owner/repo 42 --reason tolerable_risk --comment "Not reachable"
+
+  # Every open alert for an advisory across an organization
+  gh advanced-security dismiss dependabot my-org --advisory GHSA-xxxx-xxxx-xxxx --reason inaccurate
+
+  # Every open alert of a package in one manifest of a repo
+  gh advanced-security dismiss dependabot owner/repo --package lodash --manifest docs/package-lock.json --reason not_used`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Target (Org or Owner/Repo)?")
+		reason := chooseDismissReason()
+
+		// === Single Alert Mode ===
+		if strings.Contains(target, "/") && len(args) > 1 {
+			owner, repo := parseRepo(target)
+			number := parseNumber(args, 1, "Which alert number?")
+
+			fmt.Printf("Dismissing Dependabot alert #%d on %s/%s...\n", number, owner, repo)
+			if err := svc.DismissDependabotAlert(owner, repo, number, reason, dismissComment); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Println("Success!")
+			return
+		}
+
+		// === Bulk Mode ===
+		if dismissFilter.IsEmpty() {
+			fmt.Println("Error: bulk dismiss requires --advisory, --package or --manifest (or an alert number)")
+			os.Exit(1)
+		}
+
+		owner, repo := target, ""
+		if strings.Contains(target, "/") {
+			owner, repo = parseRepo(target)
+		}
+
+		fmt.Printf("Searching open Dependabot alerts in '%s'...\n", target)
+		alerts, err := svc.FindOpenDependabotAlerts(owner, repo, dismissFilter)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if len(alerts) == 0 {
+			fmt.Println("No matching open alerts.")
+			return
+		}
+
+		if err := svc.PrintDependabotAlerts(alerts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if !skipConfirm {
+			ok, err := prompt.Confirm(fmt.Sprintf("Dismiss these %d alerts as '%s'?", len(alerts), reason), false)
+			if err != nil || !ok {
+				fmt.Println("Aborted.")
+				os.Exit(0)
+			}
+		}
+
+		dismissed := svc.BulkDismissDependabotAlerts(alerts, reason, dismissComment)
+		fmt.Printf("Done! %d of %d alerts dismissed.\n", dismissed, len(alerts))
+		if dismissed != len(alerts) {
+			os.Exit(1)
+		}
+	},
+}
+
+var reopenDependabotCmd = &cobra.Command{
+	Use:     "dependabot",
+	Aliases: []string{"dep"},
+	Short:   "Reopen a dismissed Dependabot alert",
+	Example: `gh advanced-security reopen dependabot owner/repo 42`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+		number := parseNumber(args, 1, "Which alert number?")
+
+		fmt.Printf("Reopening Dependabot alert #%d on %s/%s...\n", number, owner, repo)
+		if err := svc.ReopenDependabotAlert(owner, repo, number); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println("Success!")
+	},
+}
+
+// Helper to ask for the dismiss reason when --reason is not given.
+// A --reason the API would reject stops the command before anything is fetched.
+func chooseDismissReason() string {
+	if dismissReason != "" {
+		if !slices.Contains(services.DependabotDismissReasons, dismissReason) {
+			fmt.Printf("Error: invalid dismiss reason '%s' (expected one of %s)\n", dismissReason, strings.Join(services.DependabotDismissReasons, ", "))
+			os.Exit(1)
+		}
+		return dismissReason
+	}
+	option, err := prompt.Select("Why are you dismissing?", "", services.DependabotDismissReasons)
+	if err != nil {
+		os.Exit(1)
+	}
+	return services.DependabotDismissReasons[option]
+}
+
+func init() {
+	dismissDependabotCmd.Flags().StringVarP(&dismissReason, "reason", "r", "", "Dismiss reason: "+strings.Join(services.DependabotDismissReasons, ", "))
+	dismissDependabotCmd.Flags().StringVarP(&dismissComment, "comment", "c", "", "Dismiss comment")
+	dismissDependabotCmd.Flags().StringVar(&dismissFilter.Advisory, "advisory", "", "Bulk: GHSA or CVE ID")
+	dismissDependabotCmd.Flags().StringVar(&dismissFilter.Package, "package", "", "Bulk: package name")
+	dismissDependabotCmd.Flags().StringVar(&dismissFilter.Manifest, "manifest", "", "Bulk: manifest path (e.g. package-lock.json)")
+	dismissDependabotCmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Bulk: don't ask for confirmation")
+
+	rootCmd.AddCommand(dismissCmd)
+	rootCmd.AddCommand(reopenCmd)
+	dismissCmd.AddCommand(dismissDependabotCmd)
+	reopenCmd.AddCommand(reopenDependabotCmd)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/messagedigest-net/gh-advanced-security/services"
 	"github.com/spf13/cobra"
@@ -18,22 +19,200 @@ var dependencyGraphCmd = &cobra.Command{
 	},
 }
 
+var (
+	sbomFormat string
+	sbomOutput string
+
+	searchPackage      string
+	searchVersionRange string
+	inventoryByPackage bool
+
+	licensePolicyFile string
+
+	diffFailOnSeverity string
+)
+
 var sbomCmd = &cobra.Command{
-	Use:     "sbom",
-	Short:   "Export SBOM (CycloneDX)",
-	Long:    `Export the Software Bill of Materials (SBOM) for a repository in CycloneDX format.`,
-	Example: `gh advanced-security dependency-graph sbom owner/repo > sbom.json`,
+	Use:   "sbom",
+	Short: "Export SBOM (SPDX or CycloneDX)",
+	Long: `Export the Software Bill of Materials (SBOM) for a repository, or for every repository of an organization.
+
+GitHub generates SPDX 2.3 documents; cyclonedx-json converts them to CycloneDX 1.5.
+For an organization, one SBOM per repository plus a manifest.json is written to the
+--output directory, or to a tarball when --output ends with .tar.gz or .tgz.`,
+	Example: `
+  # SPDX to the terminal
+  gh advanced-security dependency-graph sbom owner/repo
+
+  # CycloneDX to a file
+  gh advanced-security dependency-graph sbom owner/repo --format cyclonedx-json -o sbom.cdx.json
+
+  # Every repository of an organization, bundled
+  gh advanced-security dependency-graph sbom my-org --format cyclonedx-json -o my-org-sboms.tar.gz`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
+
+		if strings.Contains(target, "/") {
+			owner, repo := parseRepo(target) // Reusing helper from list-alerts.go
+
+			err := svc.ExportSBOM(owner, repo, sbomFormat, sbomOutput)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		} else {
+			output := sbomOutput
+			if output == "" {
+				output = target + "-sboms"
+			}
+
+			err := svc.BulkExportSBOM(target, sbomFormat, output)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+var dependencySearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Find the repositories using a package",
+	Long: `Search the dependency graph of a repository, or of every repository of an organization,
+for a package. Matching repositories are listed with the resolved version, license and the
+manifests declaring the package.
+
+--version-range accepts constraints like "<2.17", ">=2.0, <2.17.1" or "<1.2 || >=2.0 <2.5".`,
+	Example: `
+  gh advanced-security dependency-graph search my-org --package log4j-core --version-range '<2.17'
+  gh advanced-security dependency-graph search owner/repo --package lodash --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
+
+		if searchPackage == "" {
+			response, err := prompt.Input("Which package?", "")
+			if err != nil {
+				fmt.Printf("Unable to read input: %v\n", err)
+				os.Exit(1)
+			}
+			searchPackage = strings.TrimSpace(response)
+		}
+		if searchPackage == "" {
+			fmt.Println("A package name is required (--package)")
+			os.Exit(1)
+		}
+
+		err := svc.SearchPackage(target, searchPackage, searchVersionRange, flags.JSON)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var dependencyInventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Dependency inventory by ecosystem and license",
+	Long: `Aggregate the dependencies of a repository, or of every repository of an organization,
+by ecosystem and license. With --by-package every package is listed with its versions,
+licenses and the number of repositories using it.`,
+	Example: `
+  gh advanced-security dependency-graph inventory my-org
+  gh advanced-security dependency-graph inventory my-org --by-package --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
+
+		err := svc.Inventory(target, inventoryByPackage, flags.JSON)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var dependencyLicensesCmd = &cobra.Command{
+	Use:   "licenses",
+	Short: "Check dependency licenses against the license policy",
+	Long: `Evaluate the licenses found in the SBOM of a repository, or of every repository of an organization,
+against the allow/deny lists of the config file (~/.gh-advanced-security.yaml):
+
+  licenses:
+    allow: [MIT, Apache-2.0, BSD-*, ISC]
+    deny: [GPL-*, AGPL-*, LGPL-*]
+    fail_on_unknown: false
+
+A package is flagged when its license is denied, or not allowed when an allow list is set.
+Exits with status 1 when there are violations, or when a dependency graph can't be read
+(repositories without dependency graph are skipped, unless none has one), so it can be used as a CI gate.`,
+	Example: `
+  gh advanced-security dependency-graph licenses owner/repo
+  gh advanced-security dependency-graph licenses my-org --policy legal-policy.yaml --json`,
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetDependencyServices()
 
-		target, _ := services.GetTarget(cmd, args, "Which repository? (owner/repo)")
-		owner, repo := parseRepo(target) // Reusing helper from list-alerts.go
+		target, flags := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
 
-		err := svc.ExportSBOM(owner, repo)
+		policy, err := svc.LoadLicensePolicy(licensePolicyFile)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+
+		count, err := svc.CheckLicenses(target, policy, flags.JSON)
+		if err != nil {
+			// The violations may already be printed: keep the JSON output parseable
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if count > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var dependencyDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Review dependency changes between two refs",
+	Long: `Show the packages added, removed and updated between two refs (branches, tags or commits),
+with their licenses and known vulnerabilities - the same data as the dependency-review action.
+
+With --fail-on-severity the command exits with status 1 when the head ref introduces a
+vulnerability at or above that severity (low, moderate, high, critical).`,
+	Example: `
+  gh advanced-security dependency-graph diff owner/repo main...release/2.0
+  gh advanced-security dependency-graph diff owner/repo v1.4.0...v1.5.0-rc1 --fail-on-severity high`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+
+		var basehead string
+		if len(args) > 1 {
+			basehead = args[1]
+		} else {
+			response, err := prompt.Input("Which refs? (base...head)", "")
+			if err != nil {
+				fmt.Printf("Unable to read input: %v\n", err)
+				os.Exit(1)
+			}
+			basehead = strings.TrimSpace(response)
+		}
+
+		failing, err := svc.DiffDependencies(owner, repo, basehead, diffFailOnSeverity, flags.JSON)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if failing > 0 {
+			os.Exit(1)
+		}
 	},
 }
 
@@ -44,10 +223,10 @@ var dependabotAlertsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetDependencyServices()
 
-		target, flags := services.GetTarget(cmd, args, "Which repository? (owner/repo)")
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
 		owner, repo := parseRepo(target)
 
-		err := svc.ListDependabotAlerts(owner, repo, flags.JSON, flags.PageSize, flags.All)
+		err := svc.ListDependabotAlerts(owner, repo, dependabotListFilter, alertOwnership, flags.JSON, flags.PageSize, flags.All)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -58,5 +237,19 @@ var dependabotAlertsCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(dependencyGraphCmd)
 	dependencyGraphCmd.AddCommand(sbomCmd)
+	sbomCmd.Flags().StringVarP(&sbomFormat, "format", "f", services.SBOMFormatSPDX, "SBOM format: spdx-json or cyclonedx-json")
+	sbomCmd.Flags().StringVarP(&sbomOutput, "output", "o", "", "Output file (repo) or directory/.tar.gz (org)")
+	dependencyGraphCmd.AddCommand(dependencySearchCmd)
+	dependencySearchCmd.Flags().StringVar(&searchPackage, "package", "", "Package name, with or without its namespace (e.g. log4j-core)")
+	dependencySearchCmd.Flags().StringVar(&searchVersionRange, "version-range", "", "Only versions in this range (e.g. '<2.17')")
+	dependencyGraphCmd.AddCommand(dependencyInventoryCmd)
+	dependencyInventoryCmd.Flags().BoolVar(&inventoryByPackage, "by-package", false, "List every package instead of aggregating by license")
+	dependencyGraphCmd.AddCommand(dependencyLicensesCmd)
+	dependencyLicensesCmd.Flags().StringVar(&licensePolicyFile, "policy", "", "File with the 'licenses' policy (default: the config file)")
+	dependencyGraphCmd.AddCommand(dependencyDiffCmd)
+	dependencyDiffCmd.Flags().StringVar(&diffFailOnSeverity, "fail-on-severity", "", "Exit with status 1 on new vulnerabilities at or above this severity (low, moderate, high, critical)")
 	dependencyGraphCmd.AddCommand(dependabotAlertsCmd)
+	dependabotAlertsCmd.Flags().Float64Var(&dependabotListFilter.MinCVSS, "min-cvss", 0, "Only alerts with a CVSS score at or above this value (0-10)")
+	dependabotAlertsCmd.Flags().Float64Var(&dependabotListFilter.MinEPSSPercentile, "min-epss", 0, "Only alerts with an EPSS percentile at or above this value (0-1)")
+	addOwnershipFlags(dependabotAlertsCmd, &alertOwnership)
 }
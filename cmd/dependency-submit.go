@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var (
+	submitSha          string
+	submitRef          string
+	submitFile         string
+	submitGoBinaries   []string
+	submitRequirements []string
+	submitPurls        []string
+	submitCorrelator   string
+	submitDryRun       bool
+)
+
+var dependencySubmitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Submit dependencies GitHub can't detect (dependency submission API)",
+	Long: `Submit a dependency snapshot for a commit, so the dependency graph and Dependabot alerts
+cover dependencies that GitHub does not detect from the repository content.
+
+The snapshot is read from --file, or generated from one or more of:
+	--go-binary     Modules embedded in the build info of a Go binary
+	--requirements  A pip requirements file without lockfile (pinned versions are kept)
+	--purls         A plain list of package URLs, one per line (e.g. pkg:maven/org.acme/lib@1.2.3)
+
+Snapshots with the same correlator replace each other; by default it is derived from the manifest names.`,
+	Example: `
+  gh advanced-security dependency-graph submit owner/repo --sha $(git rev-parse HEAD) --ref main --file snapshot.json
+  gh advanced-security dependency-graph submit owner/repo --sha $(git rev-parse HEAD) --ref refs/heads/main --go-binary dist/server
+  gh advanced-security dependency-graph submit owner/repo --sha $(git rev-parse HEAD) --ref main --requirements tools/requirements.txt --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+
+		generators := len(submitGoBinaries) + len(submitRequirements) + len(submitPurls)
+		if submitFile != "" && generators > 0 {
+			fmt.Println("Use either --file or the snapshot generators (--go-binary, --requirements, --purls), not both")
+			os.Exit(1)
+		}
+		if submitFile == "" && generators == 0 {
+			fmt.Println("Nothing to submit: use --file or one of --go-binary, --requirements, --purls")
+			os.Exit(1)
+		}
+
+		snapshot := svc.NewSnapshot(submitSha, qualifyRef(submitRef), submitCorrelator)
+		if submitFile != "" {
+			loaded, err := svc.LoadSnapshot(submitFile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			snapshot = loaded
+			// Flags take precedence over the values in the file
+			if submitSha != "" {
+				snapshot.Sha = submitSha
+			}
+			if submitRef != "" {
+				snapshot.Ref = qualifyRef(submitRef)
+			}
+			if submitCorrelator != "" {
+				snapshot.Job.Correlator = submitCorrelator
+			}
+		}
+
+		for _, binary := range submitGoBinaries {
+			if err := svc.AddGoBinaryManifest(snapshot, binary); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		for _, file := range submitRequirements {
+			if err := svc.AddRequirementsManifest(snapshot, file); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		for _, file := range submitPurls {
+			if err := svc.AddPurlManifest(snapshot, file); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		if snapshot.Job.Correlator == "" {
+			snapshot.Job.Correlator = services.SnapshotCorrelator(snapshot)
+		}
+
+		if submitDryRun {
+			if err := svc.PreviewSnapshot(snapshot); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		result, err := svc.SubmitSnapshot(owner, repo, snapshot)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Snapshot #%d submitted for %s@%s: %s %s\n", result.ID, target, snapshot.Sha[:7], result.Result, result.Message)
+	},
+}
+
+// qualifyRef turns a branch name into a fully qualified ref (main -> refs/heads/main)
+func qualifyRef(ref string) string {
+	if ref == "" || strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return "refs/heads/" + ref
+}
+
+func init() {
+	dependencyGraphCmd.AddCommand(dependencySubmitCmd)
+	dependencySubmitCmd.Flags().StringVar(&submitSha, "sha", "", "Commit sha the dependencies belong to")
+	dependencySubmitCmd.Flags().StringVar(&submitRef, "ref", "", "Branch or ref of the commit (e.g. main or refs/heads/main)")
+	dependencySubmitCmd.Flags().StringVarP(&submitFile, "file", "f", "", "Snapshot JSON file to submit")
+	dependencySubmitCmd.Flags().StringSliceVar(&submitGoBinaries, "go-binary", nil, "Generate a manifest from the build info of a Go binary")
+	dependencySubmitCmd.Flags().StringSliceVar(&submitRequirements, "requirements", nil, "Generate a manifest from a pip requirements file")
+	dependencySubmitCmd.Flags().StringSliceVar(&submitPurls, "purls", nil, "Generate a manifest from a file with one package URL per line")
+	dependencySubmitCmd.Flags().StringVar(&submitCorrelator, "correlator", "", "Snapshot correlator (default: derived from the manifest names)")
+	dependencySubmitCmd.Flags().BoolVar(&submitDryRun, "dry-run", false, "Print the snapshot instead of submitting it")
+}
@@ -12,7 +12,10 @@ import (
 var disableCmd = &cobra.Command{
 	Use:   "disable",
 	Short: "Disable security features",
-	Long:  `Disable security features like Secret Scanning, Push Protection and Dependabot.`,
+	Long: `Disable security features like Secret Scanning, Push Protection and Dependabot.
+
+With --property, only the repositories of the organization with those custom property values are changed
+(e.g. --property data-classification=public).`,
 	Run: func(cmd *cobra.Command, args []string) {
 		services.ChooseSubCommand(cmd.Commands(), args, "What do you want to disable?")
 	},
@@ -25,7 +28,7 @@ var pushProtectionDisableCmd = &cobra.Command{
 gh advanced-security disable push-protection my-org`,
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetEnforcerServices()
-		target, _ := services.GetTarget(cmd, args, "Target (Org or Owner/Repo)?")
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Target (Org or Owner/Repo)?")
 
 		if strings.Contains(target, "/") {
 			parts := strings.Split(target, "/")
@@ -36,7 +39,7 @@ gh advanced-security disable push-protection my-org`,
 				os.Exit(1)
 			}
 			fmt.Println("Success!")
-		} else {
+		} else if !applyToSelection(target, services.FeaturePushProtection, false) {
 			confirmAction(target, "Push Protection", func() error {
 				return svc.BulkDisablePushProtection(target)
 			})
@@ -51,7 +54,7 @@ var secretScanningDisableCmd = &cobra.Command{
 	Example: `gh advanced-security disable secret-scanning owner/repo`,
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetEnforcerServices()
-		target, _ := services.GetTarget(cmd, args, "Target (Org or Owner/Repo)?")
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Target (Org or Owner/Repo)?")
 
 		if strings.Contains(target, "/") {
 			parts := strings.Split(target, "/")
@@ -62,7 +65,7 @@ var secretScanningDisableCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			fmt.Println("Success!")
-		} else {
+		} else if !applyToSelection(target, services.FeatureSecretScanning, false) {
 			confirmAction(target, "Secret Scanning", func() error {
 				return svc.BulkDisableSecretScanning(target)
 			})
@@ -77,7 +80,7 @@ var secretScanningNonProviderPatternsDisableCmd = &cobra.Command{
 	Example: `gh advanced-security disable non-provider-patterns owner/repo`,
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetEnforcerServices()
-		target, _ := services.GetTarget(cmd, args, "Target (Owner/Repo)?")
+		target, _ := services.GetTarget(cmd, args, services.TargetRepo, "Target (Owner/Repo)?")
 
 		if strings.Contains(target, "/") {
 			parts := strings.Split(target, "/")
@@ -88,13 +91,45 @@ var secretScanningNonProviderPatternsDisableCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			fmt.Println("Success!")
-		} else {
+		} else if !applyToSelection(target, services.FeatureNonProviderPatterns, false) {
 			fmt.Println("This setting can only be applied on individual repositories.")
 			os.Exit(1)
 		}
 	},
 }
 
+var validityChecksDisableCmd = &cobra.Command{
+	Use:     "validity-checks",
+	Aliases: []string{"vc"},
+	Short:   "Disable Secret Scanning Validity Checks",
+	Long: `Disable automatic validity checks of detected partner tokens for a repository or organization.
+
+Organizations have no validity checks setting: for an organization, they are disabled on every
+(non archived) repository with Secret Scanning enabled.`,
+	Example: `gh advanced-security disable validity-checks owner/repo
+gh advanced-security disable validity-checks my-org`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetEnforcerServices()
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Target (Org or Owner/Repo)?")
+
+		if strings.Contains(target, "/") {
+			parts := strings.Split(target, "/")
+			owner, repo := parts[0], parts[1]
+			fmt.Printf("Disabling Secret Scanning Validity Checks for %s/%s...\n", owner, repo)
+			if err := svc.DisableSecretScanningValidityChecks(owner, repo); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Println("Success!")
+		} else if !applyToSelection(target, services.FeatureValidityChecks, false) {
+			confirmChange("Disabling", target, "Secret Scanning Validity Checks", func() error {
+				return svc.BulkDisableSecretScanningValidityChecks(target)
+			})
+			fmt.Println("Success!")
+		}
+	},
+}
+
 var dependabotDisableCmd = &cobra.Command{
 	Use:     "dependabot",
 	Aliases: []string{"dep"},
@@ -102,7 +137,7 @@ var dependabotDisableCmd = &cobra.Command{
 	Example: `gh advanced-security disable dependabot owner/repo`,
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetEnforcerServices()
-		target, _ := services.GetTarget(cmd, args, "Target (Org or Owner/Repo)?")
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Target (Org or Owner/Repo)?")
 
 		if strings.Contains(target, "/") {
 			parts := strings.Split(target, "/")
@@ -117,7 +152,7 @@ var dependabotDisableCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			fmt.Println("Success! (Alerts and Updates disabled)")
-		} else {
+		} else if !applyToSelection(target, services.FeatureDependabot, false) {
 			confirmAction(target, "Dependabot (Graph, Alerts, Updates)", func() error {
 				return svc.BulkDisableDependabot(target)
 			})
@@ -127,7 +162,13 @@ var dependabotDisableCmd = &cobra.Command{
 
 // Helper para evitar repetição do prompt de confirmação
 func confirmAction(target, feature string, action func() error) {
-	fmt.Printf("Disabling %s for ALL repositories in '%s'.\n", feature, target)
+	confirmChange("Disabling", target, feature, action)
+	fmt.Println("Success! Changes will be applied asynchronously.")
+}
+
+// confirmChange asks before changing a feature on every repository of an organization, and exits on refusal or error
+func confirmChange(verb, target, feature string, action func() error) {
+	fmt.Printf("%s %s for ALL repositories in '%s'.\n", verb, feature, target)
 	fmt.Printf("Are you sure? (y/N): ")
 	var response string
 	fmt.Scanln(&response)
@@ -139,13 +180,14 @@ func confirmAction(target, feature string, action func() error) {
 		fmt.Println(err)
 		os.Exit(1)
 	}
-	fmt.Println("Success! Changes will be applied asynchronously.")
 }
 
 func init() {
 	rootCmd.AddCommand(disableCmd)
+	disableCmd.PersistentFlags().StringArrayVar(&propertySelectors, "property", nil, "Only the repositories of the organization with this custom property value (key=value, repeatable)")
 	disableCmd.AddCommand(pushProtectionDisableCmd)
 	disableCmd.AddCommand(secretScanningDisableCmd)
 	disableCmd.AddCommand(secretScanningNonProviderPatternsDisableCmd)
+	disableCmd.AddCommand(validityChecksDisableCmd)
 	disableCmd.AddCommand(dependabotDisableCmd)
 }
@@ -13,7 +13,10 @@ var enableCmd = &cobra.Command{
 	Use:     "enable",
 	Aliases: []string{"en"},
 	Short:   "Enable security features",
-	Long:    `Enable security features like Secret Scanning, Push Protection and Dependabot.`,
+	Long: `Enable security features like Secret Scanning, Push Protection and Dependabot.
+
+With --property, only the repositories of the organization with those custom property values are changed
+(e.g. --property criticality=high --property business-unit=payments).`,
 	Run: func(cmd *cobra.Command, args []string) {
 		services.ChooseSubCommand(cmd.Commands(), args, "What do you want to enable?")
 	},
@@ -33,7 +36,7 @@ var pushProtectionCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetEnforcerServices()
 
-		target, _ := services.GetTarget(cmd, args, "For which org or repo do you want to enable Push Protection?")
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "For which org or repo do you want to enable Push Protection?")
 
 		if strings.Contains(target, "/") {
 			// === Single Repo Mode ===
@@ -47,7 +50,7 @@ var pushProtectionCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			fmt.Println("Success!")
-		} else {
+		} else if !applyToSelection(target, services.FeaturePushProtection, true) {
 			// Chama o método otimizado (O(1))
 			err := svc.BulkEnablePushProtection(target)
 			if err != nil {
@@ -72,7 +75,7 @@ var secretScanningEnableCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetEnforcerServices()
 
-		target, _ := services.GetTarget(cmd, args, "For which org or repo do you want to enable Secret Scanning?")
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "For which org or repo do you want to enable Secret Scanning?")
 
 		if strings.Contains(target, "/") {
 			// === Single Repo Mode ===
@@ -86,7 +89,7 @@ var secretScanningEnableCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			fmt.Println("Success!")
-		} else {
+		} else if !applyToSelection(target, services.FeatureSecretScanning, true) {
 			// Chama o método otimizado (O(1))
 			err := svc.BulkEnableSecretScanning(target)
 			if err != nil {
@@ -108,7 +111,7 @@ var secretScanningNonProviderPatternsEnableCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetEnforcerServices()
 
-		target, _ := services.GetTarget(cmd, args, "For which repo do you want to enable Secret Scanning Non-Provider Patterns?")
+		target, _ := services.GetTarget(cmd, args, services.TargetRepo, "For which repo do you want to enable Secret Scanning Non-Provider Patterns?")
 
 		if strings.Contains(target, "/") {
 			// === Single Repo Mode ===
@@ -122,7 +125,7 @@ var secretScanningNonProviderPatternsEnableCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			fmt.Println("Success!")
-		} else {
+		} else if !applyToSelection(target, services.FeatureNonProviderPatterns, true) {
 			//
 			fmt.Println("This setting can only be applied on individual repositories.")
 			os.Exit(1)
@@ -130,6 +133,46 @@ var secretScanningNonProviderPatternsEnableCmd = &cobra.Command{
 	},
 }
 
+var validityChecksEnableCmd = &cobra.Command{
+	Use:     "validity-checks",
+	Aliases: []string{"vc"},
+	Short:   "Enable Secret Scanning Validity Checks",
+	Long: `Enable automatic validity checks of detected partner tokens for a repository or organization.
+
+Organizations have no validity checks setting: for an organization, they are enabled on every
+(non archived) repository with Secret Scanning enabled.`,
+	Example: `
+  # Enable for a single repo
+  gh advanced-security enable validity-checks owner/repo
+
+  # Enable for an entire organization
+  gh advanced-security enable validity-checks my-org`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetEnforcerServices()
+
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "For which org or repo do you want to enable Validity Checks?")
+
+		if strings.Contains(target, "/") {
+			// === Single Repo Mode ===
+			parts := strings.Split(target, "/")
+			owner, repo := parts[0], parts[1]
+
+			fmt.Printf("Enabling Secret Scanning Validity Checks for %s/%s...\n", owner, repo)
+			err := svc.EnableSecretScanningValidityChecks(owner, repo)
+			if err != nil {
+				fmt.Printf("Error: %s\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("Success!")
+		} else if !applyToSelection(target, services.FeatureValidityChecks, true) {
+			confirmChange("Enabling", target, "Secret Scanning Validity Checks", func() error {
+				return svc.BulkEnableSecretScanningValidityChecks(target)
+			})
+			fmt.Println("Success!")
+		}
+	},
+}
+
 var dependabotEnableCmd = &cobra.Command{
 	Use:     "dependabot",
 	Aliases: []string{"dep"},
@@ -144,7 +187,7 @@ var dependabotEnableCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetEnforcerServices()
 
-		target, _ := services.GetTarget(cmd, args, "Target (Org or Owner/Repo)?")
+		target, _ := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Target (Org or Owner/Repo)?")
 
 		if strings.Contains(target, "/") {
 			// === Single Repo Mode ===
@@ -166,7 +209,7 @@ var dependabotEnableCmd = &cobra.Command{
 			}
 			fmt.Println("Success! (Dependency Graph is implied/enabled by Alerts)")
 
-		} else {
+		} else if !applyToSelection(target, services.FeatureDependabot, true) {
 			// Chama o método otimizado (O(1))
 			err := svc.BulkEnableDependabot(target)
 			if err != nil {
@@ -177,10 +220,56 @@ var dependabotEnableCmd = &cobra.Command{
 	},
 }
 
+// Custom property selectors (--property key=value) of the enable and disable commands
+var propertySelectors []string
+
+// applyToSelection enables or disables a feature on the repositories of an organization matching
+// --property. Returns false when no selector is given, so the organization wide change applies.
+func applyToSelection(target, feature string, enabled bool) bool {
+	if len(propertySelectors) == 0 {
+		return false
+	}
+
+	selectors, err := services.ParsePropertySelectors(propertySelectors)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	repos, err := services.GetRepositoryServices().SelectRepositories(target, selectors)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(repos) == 0 {
+		fmt.Printf("No repository in '%s' matches %s\n", target, strings.Join(propertySelectors, ", "))
+		return true
+	}
+
+	if !enabled {
+		fmt.Printf("Disabling %s for %d repositories in '%s' (%s).\n", feature, len(repos), target, strings.Join(propertySelectors, ", "))
+		fmt.Printf("Are you sure? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" {
+			fmt.Println("Aborted.")
+			os.Exit(0)
+		}
+	}
+
+	if err := services.GetEnforcerServices().SetFeatureForRepositories(target, repos, feature, enabled); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println("Success!")
+	return true
+}
+
 func init() {
 	rootCmd.AddCommand(enableCmd)
+	enableCmd.PersistentFlags().StringArrayVar(&propertySelectors, "property", nil, "Only the repositories of the organization with this custom property value (key=value, repeatable)")
 	enableCmd.AddCommand(pushProtectionCmd)
 	enableCmd.AddCommand(secretScanningEnableCmd)
 	enableCmd.AddCommand(secretScanningNonProviderPatternsEnableCmd)
+	enableCmd.AddCommand(validityChecksEnableCmd)
 	enableCmd.AddCommand(dependabotEnableCmd)
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/messagedigest-net/gh-advanced-security/services"
@@ -31,11 +32,11 @@ var codeScanningCmd = &cobra.Command{
 		svc := services.GetAlertServices()
 
 		// Ensure we have a target repo
-		target, flags := services.GetTarget(cmd, args, "Which repository? (format: owner/repo)")
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (format: owner/repo)")
 		owner, repo := parseRepo(target)
 
 		// 'json' is the persistent flag defined in root.go
-		err := svc.ListCodeScanning(owner, repo, flags.JSON, flags.PageSize, flags.All)
+		err := svc.ListCodeScanning(owner, repo, alertOwnership, flags.JSON, flags.PageSize, flags.All)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -44,18 +45,21 @@ var codeScanningCmd = &cobra.Command{
 }
 
 // 3. Sub-Command: 'secret-scanning'
+var secretValidity string
+
 var secretScanningCmd = &cobra.Command{
 	Use:     "secret-scanning",
 	Aliases: []string{"ss", "secret"},
 	Short:   "List Secret Scanning alerts",
-	Example: "gh advanced-security list alerts secret-scanning owner/repo",
+	Example: `gh advanced-security list alerts secret-scanning owner/repo
+gh advanced-security list alerts secret-scanning owner/repo --validity active`,
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetAlertServices()
 
-		target, flags := services.GetTarget(cmd, args, "Which repository? (format: owner/repo)")
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (format: owner/repo)")
 		owner, repo := parseRepo(target)
 
-		err := svc.ListSecretScanning(owner, repo, flags.JSON, flags.PageSize, flags.All)
+		err := svc.ListSecretScanning(owner, repo, secretValidity, alertOwnership, flags.JSON, flags.PageSize, flags.All)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -63,6 +67,14 @@ var secretScanningCmd = &cobra.Command{
 	},
 }
 
+// Ownership flags shared by the alert listings and reports
+var alertOwnership services.OwnershipOptions
+
+func addOwnershipFlags(cmd *cobra.Command, opts *services.OwnershipOptions) {
+	cmd.Flags().BoolVar(&opts.Show, "owner", false, "Add an Owner column (CODEOWNERS, custom property or team permissions, see the 'ownership' config)")
+	cmd.Flags().StringSliceVar(&opts.Teams, "team", nil, "Only alerts owned by these teams (team, org/team or @org/team)")
+}
+
 // Helper to validate and split "owner/repo"
 func parseRepo(input string) (string, string) {
 	parts := strings.Split(input, "/")
@@ -73,6 +85,51 @@ func parseRepo(input string) (string, string) {
 	return parts[0], parts[1]
 }
 
+// Helper to read a numeric identifier (alert/request number) from args[index] or prompt for it
+func parseNumber(args []string, index int, message string) int {
+	var input string
+	if len(args) > index {
+		input = args[index]
+	} else {
+		response, err := prompt.Input(message, "")
+		if err != nil {
+			fmt.Printf("Unable to read input: %v\n", err)
+			os.Exit(1)
+		}
+		input = strings.TrimSpace(response)
+	}
+
+	number, err := strconv.Atoi(strings.TrimPrefix(input, "#"))
+	if err != nil || number < 1 {
+		fmt.Printf("Invalid number '%s'\n", input)
+		os.Exit(1)
+	}
+	return number
+}
+
+// Helper to read several numeric identifiers from args[index:], or prompt for them (space separated)
+func parseNumbers(args []string, index int, message string) []int {
+	inputs := args[min(index, len(args)):]
+	if len(inputs) == 0 {
+		response, err := prompt.Input(message, "")
+		if err != nil {
+			fmt.Printf("Unable to read input: %v\n", err)
+			os.Exit(1)
+		}
+		inputs = strings.Fields(response)
+	}
+
+	numbers := []int{}
+	for i := range inputs {
+		numbers = append(numbers, parseNumber(inputs, i, message))
+	}
+	if len(numbers) == 0 {
+		fmt.Println("No number given")
+		os.Exit(1)
+	}
+	return numbers
+}
+
 // In cmd/list-alerts.go (or a new cmd/list-bypasses.go)
 
 var listBypassesCmd = &cobra.Command{
@@ -82,7 +139,7 @@ var listBypassesCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		svc := services.GetAlertServices()
 
-		target, flags := services.GetTarget(cmd, args, "Which repository? (owner/repo)")
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
 		owner, repo := parseRepo(target)
 
 		err := svc.ListPushProtectionBypasses(owner, repo, flags.JSON, flags.PageSize, flags.All)
@@ -93,22 +150,25 @@ var listBypassesCmd = &cobra.Command{
 	},
 }
 
+var dependabotListFilter services.DependabotListFilter
+
 var dependabotCmd = &cobra.Command{
 	Use:     "dependabot",
 	Aliases: []string{"dep", "dependencies"},
 	Short:   "List Dependabot alerts",
-	Example: "gh advanced-security list alerts dependabot owner/repo",
+	Example: `gh advanced-security list alerts dependabot owner/repo
+gh advanced-security list alerts dependabot owner/repo --min-cvss 7 --min-epss 0.9`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 1. Get the Service (requires services/dependencyservices.go)
 		svc := services.GetDependencyServices()
 
 		// 2. Target Resolution
-		target, flags := services.GetTarget(cmd, args, "Which repository? (format: owner/repo)")
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (format: owner/repo)")
 		owner, repo := parseRepo(target)
 
 		// 3. Execution
 		// 'json' is the persistent flag from root.go
-		err := svc.ListDependabotAlerts(owner, repo, flags.JSON, flags.PageSize, flags.All)
+		err := svc.ListDependabotAlerts(owner, repo, dependabotListFilter, alertOwnership, flags.JSON, flags.PageSize, flags.All)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -121,6 +181,12 @@ func init() {
 	listCmd.AddCommand(alertsCmd)
 	alertsCmd.AddCommand(codeScanningCmd)
 	alertsCmd.AddCommand(secretScanningCmd)
+	secretScanningCmd.Flags().StringVar(&secretValidity, "validity", "", "Only alerts with this token validity: active, inactive or unknown (comma separated)")
+	addOwnershipFlags(codeScanningCmd, &alertOwnership)
+	addOwnershipFlags(secretScanningCmd, &alertOwnership)
+	addOwnershipFlags(dependabotCmd, &alertOwnership)
 	alertsCmd.AddCommand(dependabotCmd)
+	dependabotCmd.Flags().Float64Var(&dependabotListFilter.MinCVSS, "min-cvss", 0, "Only alerts with a CVSS score at or above this value (0-10)")
+	dependabotCmd.Flags().Float64Var(&dependabotListFilter.MinEPSSPercentile, "min-epss", 0, "Only alerts with an EPSS percentile at or above this value (0-1)")
 	listCmd.AddCommand(listBypassesCmd)
 }
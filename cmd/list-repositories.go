@@ -21,7 +21,7 @@ var repositoriesCmd = &cobra.Command{
 		var service services.ListerFor
 		var err error
 
-		target, flags := services.GetTarget(cmd, args, "For which org (for user, set the [-u] flag) do you want to list the repos?")
+		target, flags := services.GetTarget(cmd, args, services.TargetOrg, "Which org (or user, with the [-u] flag)?")
 
 		service = services.GetRepositoryServices()
 
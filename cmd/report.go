@@ -4,6 +4,8 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -45,9 +47,24 @@ var dependabotReportCmd = &cobra.Command{
 	},
 }
 
+var (
+	reportOwnership  services.OwnershipOptions
+	reportProperties []string
+	reportGroupBy    []string
+)
+
 // Shared logic for generating reports
 func generateReport(cmd *cobra.Command, args []string, reportType string) {
-	target, _ := services.GetTarget(cmd, args, "Which organization?")
+	target, _ := services.GetTarget(cmd, args, services.TargetOrg, "Which organization?")
+
+	var resolver *services.OwnershipResolver
+	if reportOwnership.Enabled() {
+		var err error
+		if resolver, err = services.NewOwnershipResolver(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
 
 	repoSvc := services.GetRepositoryServices()
 	fmt.Printf("Fetching repositories for %s...\n", target)
@@ -57,6 +74,27 @@ func generateReport(cmd *cobra.Command, args []string, reportType string) {
 		os.Exit(1)
 	}
 
+	// Custom properties: --property selects the repositories, --group-by adds their values as columns
+	var properties map[string]map[string][]string
+	if len(reportProperties) > 0 || len(reportGroupBy) > 0 {
+		selectors, err := services.ParsePropertySelectors(reportProperties)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if properties, err = repoSvc.FetchPropertyValues(target); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		selected := repos[:0]
+		for _, repo := range repos {
+			if selectors.Matches(properties[repo.Name]) {
+				selected = append(selected, repo)
+			}
+		}
+		repos = selected
+	}
+
 	fmt.Printf("Analyzing %d repositories. This may take a while...\n", len(repos))
 
 	// CSV File Setup
@@ -71,14 +109,20 @@ func generateReport(cmd *cobra.Command, args []string, reportType string) {
 	defer writer.Flush()
 
 	// Write Headers based on type
+	var headers []string
 	switch reportType {
 	case "code-scanning":
-		writer.Write([]string{"Repository", "Tool", "Rule", "Severity", "State", "Created At", "URL"})
+		headers = []string{"Repository", "Tool", "Rule", "Severity", "State", "Created At", "URL"}
 	case "secret-scanning":
-		writer.Write([]string{"Repository", "Secret Type", "Secret", "State", "Resolution", "Created At", "URL"})
+		headers = []string{"Repository", "Secret Type", "Secret", "State", "Validity", "Resolution", "Created At", "URL"}
 	case "dependabot":
-		writer.Write([]string{"Repository", "Package", "Severity", "State", "CVE/GHSA", "Vulnerable Version", "Created At", "URL"})
+		headers = []string{"Repository", "Package", "Severity", "State", "CVE/GHSA", "Vulnerable Version", "Created At", "URL"}
+	}
+	if reportOwnership.Show {
+		headers = append(headers, "Owner")
 	}
+	headers = append(append([]string{headers[0]}, reportGroupBy...), headers[1:]...)
+	writer.Write(headers)
 
 	// Worker Pool setup
 	type Row []string
@@ -86,6 +130,25 @@ func generateReport(cmd *cobra.Command, args []string, reportType string) {
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, 5)
 
+	// emit adds the owners of the alert file and the grouping properties of the repository to the row,
+	// skipping the alerts of other teams
+	emit := func(repoName, path string, row Row) {
+		if resolver != nil {
+			owners := resolver.Owners(target+"/"+repoName, path)
+			if len(reportOwnership.Teams) > 0 && !services.OwnedBy(owners, reportOwnership.Teams) {
+				return
+			}
+			if reportOwnership.Show {
+				row = append(row, strings.Join(owners, " "))
+			}
+		}
+		group := Row{row[0]}
+		for _, property := range reportGroupBy {
+			group = append(group, strings.Join(properties[repoName][property], ","))
+		}
+		results <- append(group, row[1:]...)
+	}
+
 	for _, repo := range repos {
 		wg.Add(1)
 		go func(repoName string) {
@@ -99,9 +162,9 @@ func generateReport(cmd *cobra.Command, args []string, reportType string) {
 				alerts, err := svc.FetchAllCodeScanning(target, repoName)
 				if err == nil {
 					for _, a := range alerts {
-						results <- []string{
+						emit(repoName, a.MostRecentInstance.Location.Path, Row{
 							repoName, a.Tool.Name, a.Rule.Id, a.Rule.Severity, a.State, a.CreatedAt, a.HtmlUrl,
-						}
+						})
 					}
 				}
 			} else if reportType == "secret-scanning" {
@@ -109,9 +172,13 @@ func generateReport(cmd *cobra.Command, args []string, reportType string) {
 				alerts, err := svc.FetchAllSecretScanning(target, repoName)
 				if err == nil {
 					for _, a := range alerts {
-						results <- []string{
-							repoName, a.SecretType, a.Secret, a.State, a.Resolution, a.CreatedAt, a.HtmlUrl,
+						path := ""
+						if a.FirstLocationDetected != nil {
+							path = a.FirstLocationDetected.Path
 						}
+						emit(repoName, path, Row{
+							repoName, a.SecretType, a.Secret, a.State, a.Validity, a.Resolution, a.CreatedAt, a.HtmlUrl,
+						})
 					}
 				}
 			} else if reportType == "dependabot" {
@@ -126,7 +193,7 @@ func generateReport(cmd *cobra.Command, args []string, reportType string) {
 							id = a.SecurityAdvisory.GHSAId
 						}
 
-						results <- []string{
+						emit(repoName, a.Dependency.ManifestPath, Row{
 							repoName,
 							a.Dependency.Package.Name,
 							a.SecurityAdvisory.Severity,
@@ -135,7 +202,7 @@ func generateReport(cmd *cobra.Command, args []string, reportType string) {
 							a.SecurityVulnerability.VulnerableVersionRange,
 							a.CreatedAt,
 							a.HtmlUrl,
-						}
+						})
 					}
 				}
 			}
@@ -149,15 +216,45 @@ func generateReport(cmd *cobra.Command, args []string, reportType string) {
 	}()
 
 	count := 0
+	groups := map[string]int{}
 	for row := range results {
 		writer.Write(row)
 		count++
 		if count%100 == 0 {
 			fmt.Printf("\rProcessed %d alerts...", count)
 		}
+		if len(reportGroupBy) > 0 {
+			groups[strings.Join(row[1:1+len(reportGroupBy)], " / ")]++
+		}
 	}
 
 	fmt.Printf("\nDone! Report saved to %s\n", filename)
+	if len(reportGroupBy) > 0 {
+		printReportGroups(groups)
+	}
+}
+
+// printReportGroups prints the alert counts by value of the --group-by properties, largest first
+func printReportGroups(groups map[string]int) {
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if groups[keys[i]] != groups[keys[j]] {
+			return groups[keys[i]] > groups[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	fmt.Printf("\nAlerts by %s:\n", strings.Join(reportGroupBy, " / "))
+	for _, k := range keys {
+		label := k
+		if strings.Trim(label, " /") == "" {
+			label = "(no value)"
+		}
+		fmt.Printf("  %-40s %d\n", label, groups[k])
+	}
 }
 
 func init() {
@@ -165,4 +262,9 @@ func init() {
 	reportCmd.AddCommand(codeScanningReportCmd)
 	reportCmd.AddCommand(secretScanningReportCmd)
 	reportCmd.AddCommand(dependabotReportCmd)
+	addOwnershipFlags(codeScanningReportCmd, &reportOwnership)
+	addOwnershipFlags(secretScanningReportCmd, &reportOwnership)
+	addOwnershipFlags(dependabotReportCmd, &reportOwnership)
+	reportCmd.PersistentFlags().StringArrayVar(&reportProperties, "property", nil, "Only the repositories with this custom property value (key=value, repeatable)")
+	reportCmd.PersistentFlags().StringSliceVar(&reportGroupBy, "group-by", nil, "Custom properties added as columns, with the alert counts by their values")
 }
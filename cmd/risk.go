@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var riskTop int
+
+var riskCmd = &cobra.Command{
+	Use:   "risk",
+	Short: "Rank the repositories of an organization by risk score",
+	Long: `Compute a risk score per repository and list the repositories from the riskiest, as a single
+prioritised queue. Archived repositories are skipped.
+
+  score = (open alerts weighted by severity
+           + active secrets * active_secret
+           + Dependabot alerts * EPSS percentile * epss
+           + missing coverage features * missing_feature)
+          * visibility multiplier * custom property multipliers
+
+Coverage features: code-scanning, secret-scanning, push-protection and dependabot-security-updates.
+The weights can be changed in the "risk" section of the config file (~/.gh-advanced-security.yaml):
+
+  risk:
+    severity: {critical: 10, high: 5, medium: 2, low: 1}
+    active_secret: 10
+    epss: 10
+    missing_feature: 5
+    visibility: {public: 2, internal: 1.5, private: 1}
+    properties:
+      criticality: {high: 2, low: 0.5}
+      data-classification: {restricted: 3}`,
+	Example: `
+  gh advanced-security risk my-org
+  gh advanced-security risk my-org --top 20 --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetRepositoryServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetOrg, "Which organization?")
+
+		if err := svc.Risk(target, riskTop, flags.JSON); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(riskCmd)
+	riskCmd.Flags().IntVar(&riskTop, "top", 0, "Only the N riskiest repositories")
+}
@@ -16,6 +16,12 @@ var rootCmd = &cobra.Command{
   Run: func(cmd *cobra.Command, args []string) {
     services.ChooseSubCommand(cmd.Commands(), args, "What do you want to do?")
   },
+  // Only runs when the command completed: failing commands exit before
+  PersistentPostRun: func(cmd *cobra.Command, args []string) {
+    if err := services.RememberResolvedTarget(); err != nil {
+      fmt.Fprintf(os.Stderr, "Unable to remember the target: %v\n", err)
+    }
+  },
 }
 
 // Global prompter variable can stay if used widely,
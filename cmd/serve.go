@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var webhookOptions services.WebhookOptions
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Receive GitHub webhooks and react to security events",
+	Long: `Run a webhook receiver for an organization (or repository) webhook. Deliveries must be signed
+with the webhook secret (X-Hub-Signature-256), the others are rejected.
+
+Handled events:
+  repository               on created and transferred, enable the --enable features on the repository.
+                           The organization defaults for new repositories don't cover transferred ones.
+  code_scanning_alert      report new, reopened and resolved alerts, and send them to the --notifier
+  secret_scanning_alert    notifiers of the config file (see 'alerts notify --help').
+  dependabot_alert         With --json, one JSON event per line.
+
+The secret can be given with the GHAS_WEBHOOK_SECRET environment variable instead of --secret.`,
+	Example: `
+  gh advanced-security serve --listen :8080 --secret "$WEBHOOK_SECRET"
+  gh advanced-security serve --listen :8080 --enable secret-scanning,push-protection --notifier soc --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := services.GetGlobalFlags()
+
+		if webhookOptions.Secret == "" {
+			webhookOptions.Secret = os.Getenv("GHAS_WEBHOOK_SECRET")
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := services.ServeWebhooks(ctx, webhookOptions, flags.JSON); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&webhookOptions.Listen, "listen", ":8080", "Address to listen on")
+	serveCmd.Flags().StringVar(&webhookOptions.Path, "path", "/", "URL path of the webhook")
+	serveCmd.Flags().StringVar(&webhookOptions.Secret, "secret", "", "Webhook secret (default: $GHAS_WEBHOOK_SECRET)")
+	serveCmd.Flags().StringSliceVar(&webhookOptions.Enable, "enable", []string{services.FeatureSecretScanning, services.FeaturePushProtection, services.FeatureDependabot},
+		"Features enabled on new repositories: "+strings.Join(services.RepositoryFeatures, ", "))
+	serveCmd.Flags().StringSliceVar(&webhookOptions.Notifiers, "notifier", nil, "Send the alert changes to these notifiers of the config file")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var showSecretAlertCmd = &cobra.Command{
+	Use:     "secret-alert",
+	Aliases: []string{"ss-alert", "secret"},
+	Short:   "Show a Secret Scanning alert and where the secret appeared",
+	Long: `Show a Secret Scanning alert with every location where the secret was found:
+	- Commits (file path and line range)
+	- Issue, pull request and discussion titles, bodies and comments
+	- Wiki commits`,
+	Example: `gh advanced-security show secret-alert owner/repo 12`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+		number := parseNumber(args, 1, "Which alert number?")
+
+		err := svc.ShowSecretScanningAlert(owner, repo, number, flags.JSON)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var showCodeAlertCmd = &cobra.Command{
+	Use:     "code-alert",
+	Aliases: []string{"cs-alert", "code"},
+	Short:   "Show a Code Scanning alert with its instances and source",
+	Long: `Show a Code Scanning alert with:
+	- Its instances across branches and analyses
+	- A source snippet of the flagged lines at the commit of the most recent instance
+	- The full help text of the rule`,
+	Example: `gh advanced-security show code-alert owner/repo 7`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+		number := parseNumber(args, 1, "Which alert number?")
+
+		err := svc.ShowCodeScanningAlert(owner, repo, number, flags.JSON)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var showDependabotAlertCmd = &cobra.Command{
+	Use:     "dependabot-alert",
+	Aliases: []string{"dep-alert", "dependabot"},
+	Short:   "Show a Dependabot alert with CVSS, CWE, EPSS and patched version",
+	Example: `gh advanced-security show dependabot-alert owner/repo 42`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetDependencyServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository? (owner/repo)")
+		owner, repo := parseRepo(target)
+		number := parseNumber(args, 1, "Which alert number?")
+
+		err := svc.ShowDependabotAlert(owner, repo, number, flags.JSON)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	showCmd.AddCommand(showSecretAlertCmd)
+	showCmd.AddCommand(showCodeAlertCmd)
+	showCmd.AddCommand(showDependabotAlertCmd)
+}
@@ -31,7 +31,7 @@ var organizationCmd = &cobra.Command{
 
 		service = services.GetOrganizationServices()
 
-		target, flags := services.GetTarget(cmd, args, "Which organization do you want to show?")
+		target, flags := services.GetTarget(cmd, args, services.TargetOrg, "Which organization do you want to show?")
 
 		err = service.Show(target, flags.JSON)
 		if err != nil {
@@ -19,7 +19,7 @@ var showRepoCmd = &cobra.Command{
 		svc := services.GetRepositoryServices()
 
 		// Interactive target selection
-		target, flags := services.GetTarget(cmd, args, "Which repository do you want to show? (owner/repo)")
+		target, flags := services.GetTarget(cmd, args, services.TargetRepo, "Which repository do you want to show? (owner/repo)")
 
 		// Validate input format
 		if !strings.Contains(target, "/") {
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/messagedigest-net/gh-advanced-security/services"
+	"github.com/spf13/cobra"
+)
+
+var watchOptions services.WatchOptions
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Continuously monitor a repository or organization for alert changes",
+	Long: `Poll the open alerts of a repository or organization and report the new, reopened and resolved
+(fixed, dismissed or closed) alerts as they happen, until interrupted.
+
+Requests use ETags (If-None-Match): pages that did not change are answered with 304 Not Modified,
+which doesn't count against the rate limit. The first poll records the open alerts as the baseline,
+unless --state points to a snapshot of a previous watch or 'alerts notify' run.
+
+With --json every change is written to stdout as one JSON event per line, and status lines go to stderr.
+With --notifier the changes are also sent to notifiers of the config file (see 'alerts notify --help').`,
+	Example: `
+  gh advanced-security watch my-org --interval 5m
+  gh advanced-security watch my-org --type secret-scanning --notifier soc --json >> events.ndjson`,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc := services.GetAlertServices()
+
+		target, flags := services.GetTarget(cmd, args, services.TargetOrgOrRepo, "Which repository or organization? (owner/repo or org)")
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := svc.WatchAlerts(ctx, target, watchOptions, flags.JSON); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().DurationVar(&watchOptions.Interval, "interval", 5*time.Minute, "Time between polls (minimum 30s)")
+	watchCmd.Flags().StringSliceVar(&watchOptions.Types, "type", services.AlertTypes, "Alert types: "+strings.Join(services.AlertTypes, ", "))
+	watchCmd.Flags().StringSliceVar(&watchOptions.Notifiers, "notifier", nil, "Send the changes to these notifiers of the config file")
+	watchCmd.Flags().StringVar(&watchOptions.StateFile, "state", "", "Snapshot file to resume from and keep updated")
+}
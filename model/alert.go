@@ -1,7 +1,7 @@
 package model
 
 type Alert struct {
-	Numer              int
+	Numer              int    `json:"number"`
 	CreatedAt          string `json:"created_at"`
 	URL                string
 	HtmlUrl            string `json:"html_url"`
@@ -15,4 +15,6 @@ type Alert struct {
 	MostRecentInstance Instance `json:"most_recent_instance"`
 	InstancesUrl       string   `json:"instances_url"`
 	Repository         Repository
+	Instances          []Instance `json:"instances,omitempty"`
+	Owners             []string   `json:"owners,omitempty"` // resolved by the ownership resolver, not part of the API
 }
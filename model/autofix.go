@@ -0,0 +1,66 @@
+package model
+
+// Autofix maps to GET/POST /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}/autofix
+type Autofix struct {
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	StartedAt   string `json:"started_at"`
+}
+
+// AutofixStatus is the autofix of one alert, as listed by the status command
+type AutofixStatus struct {
+	Alert int    `json:"alert"`
+	Rule  string `json:"rule"`
+	Path  string `json:"path"`
+	Autofix
+	Error string `json:"error,omitempty"`
+}
+
+// AutofixCommitRequest is the body of POST /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}/autofix/commits
+type AutofixCommitRequest struct {
+	TargetRef string `json:"target_ref"`
+	Message   string `json:"message,omitempty"`
+}
+
+type AutofixCommitResponse struct {
+	TargetRef string `json:"target_ref"`
+	Sha       string `json:"sha"`
+}
+
+// GitRef maps to GET /repos/{owner}/{repo}/git/ref/{ref}
+type GitRef struct {
+	Ref    string `json:"ref"`
+	Object struct {
+		Sha string `json:"sha"`
+	} `json:"object"`
+}
+
+// CreateRefRequest is the body of POST /repos/{owner}/{repo}/git/refs
+type CreateRefRequest struct {
+	Ref string `json:"ref"`
+	Sha string `json:"sha"`
+}
+
+// CreatePullRequest is the body of POST /repos/{owner}/{repo}/pulls
+type CreatePullRequest struct {
+	Title string `json:"title"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Body  string `json:"body"`
+}
+
+type PullRequest struct {
+	Number  int    `json:"number"`
+	HtmlUrl string `json:"html_url"`
+}
+
+// Comparison maps to GET /repos/{owner}/{repo}/compare/{basehead}
+type Comparison struct {
+	Files []ComparisonFile `json:"files"`
+}
+
+type ComparisonFile struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+	Patch    string `json:"patch"`
+}
@@ -11,3 +11,43 @@ type PushProtectionBypass struct {
 	Requester        User   `json:"requester"`
 	RequesterComment string `json:"requester_comment"`
 }
+
+// BypassRequest maps to a delegated bypass request for secret scanning push protection
+// Docs: GET /repos/{owner}/{repo}/bypass-requests/secret-scanning
+type BypassRequest struct {
+	ID                 int                 `json:"id"`
+	Number             int                 `json:"number"`
+	Repository         Repository          `json:"repository"`
+	Requester          BypassActor         `json:"requester"`
+	RequestType        string              `json:"request_type"`
+	Data               []BypassRequestData `json:"data"`
+	ResourceIdentifier string              `json:"resource_identifier"`
+	Status             string              `json:"status"` // e.g., "open", "approved", "denied", "expired"
+	RequesterComment   string              `json:"requester_comment"`
+	ExpiresAt          string              `json:"expires_at"`
+	CreatedAt          string              `json:"created_at"`
+	Responses          []BypassResponse    `json:"responses"`
+	URL                string              `json:"url"`
+	HtmlUrl            string              `json:"html_url"`
+}
+
+type BypassRequestData struct {
+	SecretType   string `json:"secret_type"`
+	BypassReason string `json:"bypass_reason"`
+	Path         string `json:"path"`
+	Branch       string `json:"branch"`
+	RulesetID    int    `json:"ruleset_id"`
+	RulesetName  string `json:"ruleset_name"`
+}
+
+type BypassActor struct {
+	ActorID   int    `json:"actor_id"`
+	ActorName string `json:"actor_name"`
+}
+
+type BypassResponse struct {
+	ID        int         `json:"id"`
+	Reviewer  BypassActor `json:"reviewer"`
+	Status    string      `json:"status"`
+	CreatedAt string      `json:"created_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+type CodeSecurityConfiguration struct {
+	ID                                   int                    `json:"id"`
+	Name                                 string                 `json:"name"`
+	TargetType                           string                 `json:"target_type"`
+	Description                          string                 `json:"description"`
+	SecretScanning                       string                 `json:"secret_scanning"`
+	SecretScanningPushProtection         string                 `json:"secret_scanning_push_protection"`
+	SecretScanningDelegatedBypass        string                 `json:"secret_scanning_delegated_bypass"`
+	SecretScanningDelegatedBypassOptions DelegatedBypassOptions `json:"secret_scanning_delegated_bypass_options"`
+	HtmlUrl                              string                 `json:"html_url"`
+}
+
+type DelegatedBypassOptions struct {
+	Reviewers []BypassReviewer `json:"reviewers"`
+}
+
+type BypassReviewer struct {
+	ReviewerID   int    `json:"reviewer_id"`
+	ReviewerType string `json:"reviewer_type"` // "TEAM" or "ROLE"
+}
@@ -0,0 +1,15 @@
+package model
+
+// Content maps to GET /repos/{owner}/{repo}/contents/{path} for a single file
+type Content struct {
+	Type        string
+	Encoding    string
+	Size        int
+	Name        string
+	Path        string
+	Content     string
+	Sha         string
+	URL         string
+	HtmlUrl     string `json:"html_url"`
+	DownloadUrl string `json:"download_url"`
+}
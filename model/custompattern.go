@@ -0,0 +1,53 @@
+package model
+
+// CustomPattern is a local definition of a secret scanning custom pattern,
+// using the same fields as the "New pattern" form on GitHub.
+type CustomPattern struct {
+	Name               string   `json:"name" mapstructure:"name"`
+	SecretFormat       string   `json:"secret_format" mapstructure:"secret_format"`
+	BeforeSecret       string   `json:"before_secret" mapstructure:"before_secret"`
+	AfterSecret        string   `json:"after_secret" mapstructure:"after_secret"`
+	AdditionalMatch    []string `json:"additional_match" mapstructure:"additional_match"`
+	AdditionalNotMatch []string `json:"additional_not_match" mapstructure:"additional_not_match"`
+}
+
+// CustomPatternMatch is a single hit of a CustomPattern in a sample file
+type CustomPatternMatch struct {
+	Pattern string `json:"pattern"`
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Secret  string `json:"secret"`
+}
+
+// PatternConfigurations maps to GET /orgs/{org}/secret-scanning/pattern-configurations
+type PatternConfigurations struct {
+	PatternConfigVersion     string            `json:"pattern_config_version"`
+	ProviderPatternOverrides []PatternOverride `json:"provider_pattern_overrides"`
+	CustomPatternOverrides   []PatternOverride `json:"custom_pattern_overrides"`
+}
+
+type PatternOverride struct {
+	TokenType            string  `json:"token_type"`
+	CustomPatternVersion string  `json:"custom_pattern_version"`
+	Slug                 string  `json:"slug"`
+	DisplayName          string  `json:"display_name"`
+	AlertTotal           int     `json:"alert_total"`
+	AlertTotalPercentage float64 `json:"alert_total_percentage"`
+	FalsePositives       int     `json:"false_positives"`
+	FalsePositiveRate    float64 `json:"false_positive_rate"`
+	BypassRate           float64 `json:"bypass_rate"`
+	DefaultSetting       string  `json:"default_setting"`
+	EnterpriseSetting    string  `json:"enterprise_setting"`
+	Setting              string  `json:"setting"`
+}
+
+// RepositoryCustomPattern is a custom pattern applying to a repository, with the alerts it raised there
+type RepositoryCustomPattern struct {
+	Name           string `json:"name"`
+	TokenType      string `json:"token_type"`
+	DefinedAt      string `json:"defined_at"` // organization, or unknown for the patterns only known from alerts
+	PushProtection string `json:"push_protection,omitempty"`
+	OpenAlerts     int    `json:"open_alerts"`
+	TotalAlerts    int    `json:"total_alerts"`
+}
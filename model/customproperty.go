@@ -0,0 +1,16 @@
+package model
+
+// CustomPropertyValue maps to GET /repos/{owner}/{repo}/properties/values
+// Value is a string, or a list of strings for multi_select properties (null when unset)
+type CustomPropertyValue struct {
+	PropertyName string      `json:"property_name"`
+	Value        interface{} `json:"value"`
+}
+
+// RepositoryPropertyValues maps to GET /orgs/{org}/properties/values
+type RepositoryPropertyValues struct {
+	RepositoryID       int                   `json:"repository_id"`
+	RepositoryName     string                `json:"repository_name"`
+	RepositoryFullName string                `json:"repository_full_name"`
+	Properties         []CustomPropertyValue `json:"properties"`
+}
@@ -0,0 +1,49 @@
+package model
+
+// CycloneDXBOM is a CycloneDX 1.5 JSON document
+type CycloneDXBOM struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     CycloneDXMetadata     `json:"metadata"`
+	Components   []CycloneDXComponent  `json:"components"`
+	Dependencies []CycloneDXDependency `json:"dependencies,omitempty"`
+}
+
+type CycloneDXMetadata struct {
+	Timestamp string              `json:"timestamp,omitempty"`
+	Tools     CycloneDXTools      `json:"tools"`
+	Component *CycloneDXComponent `json:"component,omitempty"`
+}
+
+type CycloneDXTools struct {
+	Components []CycloneDXComponent `json:"components"`
+}
+
+type CycloneDXComponent struct {
+	Type      string             `json:"type"`
+	BOMRef    string             `json:"bom-ref,omitempty"`
+	Group     string             `json:"group,omitempty"`
+	Name      string             `json:"name"`
+	Version   string             `json:"version,omitempty"`
+	Purl      string             `json:"purl,omitempty"`
+	Copyright string             `json:"copyright,omitempty"`
+	Licenses  []CycloneDXLicense `json:"licenses,omitempty"`
+}
+
+// CycloneDXLicense holds either a single license (ID or name) or an SPDX expression
+type CycloneDXLicense struct {
+	License    *CycloneDXLicenseID `json:"license,omitempty"`
+	Expression string              `json:"expression,omitempty"`
+}
+
+type CycloneDXLicenseID struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type CycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
@@ -14,6 +14,10 @@ type DependabotAlert struct {
 	DismissedBy           User                  `json:"dismissed_by"`
 	DismissedReason       string                `json:"dismissed_reason"`
 	DismissedComment      string                `json:"dismissed_comment"`
+	FixedAt               string                `json:"fixed_at"`
+	AutoDismissedAt       string                `json:"auto_dismissed_at"`
+	Repository            Repository            `json:"repository"`       // only on organization level listings
+	Owners                []string              `json:"owners,omitempty"` // resolved by the ownership resolver, not part of the API
 }
 
 type Dependency struct {
@@ -28,15 +32,59 @@ type Package struct {
 }
 
 type SecurityAdvisory struct {
-	GHSAId      string `json:"ghsa_id"`
-	CVEId       string `json:"cve_id"`
-	Summary     string `json:"summary"`
-	Description string `json:"description"`
-	Severity    string `json:"severity"`
+	GHSAId         string               `json:"ghsa_id"`
+	CVEId          string               `json:"cve_id"`
+	Summary        string               `json:"summary"`
+	Description    string               `json:"description"`
+	Severity       string               `json:"severity"`
+	CVSS           CVSS                 `json:"cvss"`
+	CVSSSeverities CVSSSeverities       `json:"cvss_severities"`
+	CWEs           []CWE                `json:"cwes"`
+	EPSS           []EPSS               `json:"epss"`
+	Identifiers    []AdvisoryIdentifier `json:"identifiers"`
+	References     []AdvisoryReference  `json:"references"`
+	PublishedAt    string               `json:"published_at"`
+	UpdatedAt      string               `json:"updated_at"`
+	WithdrawnAt    string               `json:"withdrawn_at"`
 }
 
 type SecurityVulnerability struct {
-	Package                Package `json:"package"`
-	Severity               string  `json:"severity"`
-	VulnerableVersionRange string  `json:"vulnerable_version_range"`
+	Package                Package        `json:"package"`
+	Severity               string         `json:"severity"`
+	VulnerableVersionRange string         `json:"vulnerable_version_range"`
+	FirstPatchedVersion    PatchedVersion `json:"first_patched_version"`
+}
+
+type PatchedVersion struct {
+	Identifier string `json:"identifier"`
+}
+
+type CVSS struct {
+	VectorString string  `json:"vector_string"`
+	Score        float64 `json:"score"`
+}
+
+type CVSSSeverities struct {
+	CVSSV3 CVSS `json:"cvss_v3"`
+	CVSSV4 CVSS `json:"cvss_v4"`
+}
+
+type CWE struct {
+	CWEId string `json:"cwe_id"`
+	Name  string `json:"name"`
+}
+
+// EPSS is the Exploit Prediction Scoring System data (both values range from 0 to 1)
+type EPSS struct {
+	Percentage float64 `json:"percentage"`
+	Percentile float64 `json:"percentile"`
+}
+
+type AdvisoryIdentifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type AdvisoryReference struct {
+	URL string `json:"url"`
 }
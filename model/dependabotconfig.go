@@ -0,0 +1,55 @@
+package model
+
+// DependabotConfig maps to .github/dependabot.yml (version 2).
+// Settings the audit doesn't inspect are kept as interface{} so unknown keys can still be detected.
+type DependabotConfig struct {
+	Version              int                `yaml:"version" json:"version"`
+	EnableBetaEcosystems bool               `yaml:"enable-beta-ecosystems,omitempty" json:"enable_beta_ecosystems,omitempty"`
+	Registries           interface{}        `yaml:"registries,omitempty" json:"registries,omitempty"`
+	MultiEcosystemGroups interface{}        `yaml:"multi-ecosystem-groups,omitempty" json:"multi_ecosystem_groups,omitempty"`
+	Updates              []DependabotUpdate `yaml:"updates" json:"updates"`
+}
+
+type DependabotUpdate struct {
+	PackageEcosystem              string             `yaml:"package-ecosystem" json:"package_ecosystem"`
+	Directory                     string             `yaml:"directory,omitempty" json:"directory,omitempty"`
+	Directories                   []string           `yaml:"directories,omitempty" json:"directories,omitempty"`
+	Schedule                      DependabotSchedule `yaml:"schedule" json:"schedule"`
+	Allow                         interface{}        `yaml:"allow,omitempty" json:"allow,omitempty"`
+	Assignees                     interface{}        `yaml:"assignees,omitempty" json:"assignees,omitempty"`
+	CommitMessage                 interface{}        `yaml:"commit-message,omitempty" json:"commit_message,omitempty"`
+	Cooldown                      interface{}        `yaml:"cooldown,omitempty" json:"cooldown,omitempty"`
+	ExcludePaths                  interface{}        `yaml:"exclude-paths,omitempty" json:"exclude_paths,omitempty"`
+	Groups                        interface{}        `yaml:"groups,omitempty" json:"groups,omitempty"`
+	Ignore                        interface{}        `yaml:"ignore,omitempty" json:"ignore,omitempty"`
+	InsecureExternalCodeExecution interface{}        `yaml:"insecure-external-code-execution,omitempty" json:"insecure_external_code_execution,omitempty"`
+	Labels                        interface{}        `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Milestone                     interface{}        `yaml:"milestone,omitempty" json:"milestone,omitempty"`
+	MultiEcosystemGroup           interface{}        `yaml:"multi-ecosystem-group,omitempty" json:"multi_ecosystem_group,omitempty"`
+	OpenPullRequestsLimit         interface{}        `yaml:"open-pull-requests-limit,omitempty" json:"open_pull_requests_limit,omitempty"`
+	Patterns                      interface{}        `yaml:"patterns,omitempty" json:"patterns,omitempty"`
+	PullRequestBranchName         interface{}        `yaml:"pull-request-branch-name,omitempty" json:"pull_request_branch_name,omitempty"`
+	RebaseStrategy                interface{}        `yaml:"rebase-strategy,omitempty" json:"rebase_strategy,omitempty"`
+	Registries                    interface{}        `yaml:"registries,omitempty" json:"registries,omitempty"`
+	Reviewers                     interface{}        `yaml:"reviewers,omitempty" json:"reviewers,omitempty"`
+	TargetBranch                  interface{}        `yaml:"target-branch,omitempty" json:"target_branch,omitempty"`
+	Vendor                        interface{}        `yaml:"vendor,omitempty" json:"vendor,omitempty"`
+	VersioningStrategy            interface{}        `yaml:"versioning-strategy,omitempty" json:"versioning_strategy,omitempty"`
+}
+
+type DependabotSchedule struct {
+	Interval string `yaml:"interval" json:"interval"`
+	Day      string `yaml:"day,omitempty" json:"day,omitempty"`
+	Time     string `yaml:"time,omitempty" json:"time,omitempty"`
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty"`
+	Cronjob  string `yaml:"cronjob,omitempty" json:"cronjob,omitempty"`
+}
+
+// DependabotConfigAudit is the result of auditing the dependabot.yml of a repository
+type DependabotConfigAudit struct {
+	Repository string   `json:"repository"`
+	File       string   `json:"file"`
+	Status     string   `json:"status"`
+	Errors     []string `json:"errors"`
+	Missing    []string `json:"missing"`
+}
@@ -0,0 +1,84 @@
+package model
+
+// DependabotPullRequest is an open pull request opened by Dependabot, with the alerts it fixes
+type DependabotPullRequest struct {
+	Repository  string                  `json:"repository"`
+	Number      int                     `json:"number"`
+	Title       string                  `json:"title"`
+	URL         string                  `json:"url"`
+	Branch      string                  `json:"branch"`
+	HeadSha     string                  `json:"head_sha"`
+	CreatedAt   string                  `json:"created_at"`
+	AgeDays     int                     `json:"age_days"`
+	Package     string                  `json:"package"`
+	FromVersion string                  `json:"from_version"`
+	ToVersion   string                  `json:"to_version"`
+	UpdateLevel string                  `json:"update_level"`
+	Checks      string                  `json:"checks"`
+	Mergeable   string                  `json:"mergeable"`
+	Draft       bool                    `json:"draft"`
+	Alerts      []DependabotLinkedAlert `json:"alerts"`
+}
+
+// DependabotLinkedAlert is a Dependabot alert fixed by a security update pull request
+type DependabotLinkedAlert struct {
+	Number   int    `json:"number"`
+	GHSAId   string `json:"ghsa_id"`
+	Severity string `json:"severity"`
+}
+
+// PullRequestSearch maps to the GraphQL search connection, restricted to pull requests
+type PullRequestSearch struct {
+	PageInfo PageInfo          `json:"pageInfo"`
+	Nodes    []PullRequestNode `json:"nodes"`
+}
+
+type PullRequestNode struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	CreatedAt   string `json:"createdAt"`
+	HeadRefName string `json:"headRefName"`
+	Mergeable   string `json:"mergeable"`
+	IsDraft     bool   `json:"isDraft"`
+	Repository  struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				Oid               string `json:"oid"`
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// VulnerabilityAlerts maps to the GraphQL repository.vulnerabilityAlerts connection
+type VulnerabilityAlerts struct {
+	PageInfo PageInfo                 `json:"pageInfo"`
+	Nodes    []VulnerabilityAlertNode `json:"nodes"`
+}
+
+type VulnerabilityAlertNode struct {
+	Number           int `json:"number"`
+	SecurityAdvisory struct {
+		GHSAId string `json:"ghsaId"`
+	} `json:"securityAdvisory"`
+	SecurityVulnerability struct {
+		Severity string `json:"severity"`
+	} `json:"securityVulnerability"`
+	DependabotUpdate *struct {
+		PullRequest *struct {
+			Number int `json:"number"`
+		} `json:"pullRequest"`
+	} `json:"dependabotUpdate"`
+}
+
+// MergePullRequest is the body of PUT /repos/{owner}/{repo}/pulls/{pull_number}/merge
+type MergePullRequest struct {
+	MergeMethod string `json:"merge_method"`
+	Sha         string `json:"sha,omitempty"`
+}
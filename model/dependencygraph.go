@@ -0,0 +1,57 @@
+package model
+
+// DependencyGraphManifests maps to the GraphQL repository.dependencyGraphManifests connection
+type DependencyGraphManifests struct {
+	PageInfo PageInfo                  `json:"pageInfo"`
+	Nodes    []DependencyGraphManifest `json:"nodes"`
+}
+
+type DependencyGraphManifest struct {
+	ID           string                      `json:"id"`
+	Filename     string                      `json:"filename"`
+	Dependencies DependencyGraphDependencies `json:"dependencies"`
+}
+
+type DependencyGraphDependencies struct {
+	PageInfo PageInfo                    `json:"pageInfo"`
+	Nodes    []DependencyGraphDependency `json:"nodes"`
+}
+
+type DependencyGraphDependency struct {
+	PackageName    string `json:"packageName"`
+	PackageManager string `json:"packageManager"`
+	Requirements   string `json:"requirements"`
+}
+
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// PackageUsage is a package found in the dependency graph of a repository
+type PackageUsage struct {
+	Repository string   `json:"repository"`
+	Ecosystem  string   `json:"ecosystem"`
+	Name       string   `json:"name"`
+	Version    string   `json:"version"`
+	License    string   `json:"license"`
+	Purl       string   `json:"purl,omitempty"`
+	Manifests  []string `json:"manifests,omitempty"`
+}
+
+// InventoryEntry aggregates packages of an organization by ecosystem and license
+type InventoryEntry struct {
+	Ecosystem    string `json:"ecosystem"`
+	License      string `json:"license"`
+	Packages     int    `json:"packages"`
+	Repositories int    `json:"repositories"`
+}
+
+// InventoryPackage aggregates the usages of a single package across an organization
+type InventoryPackage struct {
+	Ecosystem    string   `json:"ecosystem"`
+	Name         string   `json:"name"`
+	Versions     []string `json:"versions"`
+	Licenses     []string `json:"licenses"`
+	Repositories []string `json:"repositories"`
+}
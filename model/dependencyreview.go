@@ -0,0 +1,36 @@
+package model
+
+// DependencyChange maps to an entry of GET /repos/{owner}/{repo}/dependency-graph/compare/{basehead}
+type DependencyChange struct {
+	ChangeType          string                    `json:"change_type"`
+	Manifest            string                    `json:"manifest"`
+	Ecosystem           string                    `json:"ecosystem"`
+	Name                string                    `json:"name"`
+	Version             string                    `json:"version"`
+	PackageURL          string                    `json:"package_url"`
+	License             string                    `json:"license"`
+	SourceRepositoryURL string                    `json:"source_repository_url"`
+	Scope               string                    `json:"scope"`
+	Vulnerabilities     []DependencyVulnerability `json:"vulnerabilities"`
+}
+
+type DependencyVulnerability struct {
+	Severity        string `json:"severity"`
+	AdvisoryGHSAId  string `json:"advisory_ghsa_id"`
+	AdvisorySummary string `json:"advisory_summary"`
+	AdvisoryURL     string `json:"advisory_url"`
+}
+
+// DependencyDiffEntry is a package added, removed or updated (removed and added with another version)
+// between two refs
+type DependencyDiffEntry struct {
+	Change          string                    `json:"change"`
+	Manifest        string                    `json:"manifest"`
+	Ecosystem       string                    `json:"ecosystem"`
+	Name            string                    `json:"name"`
+	FromVersion     string                    `json:"from_version,omitempty"`
+	ToVersion       string                    `json:"to_version,omitempty"`
+	License         string                    `json:"license"`
+	Scope           string                    `json:"scope"`
+	Vulnerabilities []DependencyVulnerability `json:"vulnerabilities"`
+}
@@ -7,6 +7,7 @@ type Instance struct {
 	Environment     string
 	State           string
 	CommitSha       string `json:"commit_sha"`
+	HtmlUrl         string `json:"html_url"`
 	Message         Message
 	Location        Location
 	Classifications []Classification
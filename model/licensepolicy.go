@@ -0,0 +1,19 @@
+package model
+
+// LicensePolicy is the "licenses" section of the config file.
+// Entries are SPDX identifiers and may use * wildcards (e.g. "GPL-*").
+type LicensePolicy struct {
+	Allow         []string `json:"allow" mapstructure:"allow"`
+	Deny          []string `json:"deny" mapstructure:"deny"`
+	FailOnUnknown bool     `json:"fail_on_unknown" mapstructure:"fail_on_unknown"`
+}
+
+// LicenseViolation is a package whose license breaks the LicensePolicy
+type LicenseViolation struct {
+	Repository string `json:"repository"`
+	Ecosystem  string `json:"ecosystem"`
+	Package    string `json:"package"`
+	Version    string `json:"version"`
+	License    string `json:"license"`
+	Reason     string `json:"reason"`
+}
@@ -0,0 +1,36 @@
+package model
+
+// NotifierConfig is an entry of the "notifiers" list of the config file.
+// Type is webhook, slack, teams or jira; URL, Token and Headers values may reference
+// environment variables ($VAR or ${VAR}).
+type NotifierConfig struct {
+	Name         string            `mapstructure:"name"`
+	Type         string            `mapstructure:"type"`
+	URL          string            `mapstructure:"url"`
+	Headers      map[string]string `mapstructure:"headers"`
+	Events       []string          `mapstructure:"events"`
+	Types        []string          `mapstructure:"types"`
+	MinSeverity  string            `mapstructure:"min_severity"`
+	Repositories string            `mapstructure:"repositories"` // regular expression on the repository full name
+	Project      string            `mapstructure:"project"`      // jira
+	IssueType    string            `mapstructure:"issue_type"`   // jira
+	Labels       []string          `mapstructure:"labels"`       // jira
+	User         string            `mapstructure:"user"`         // jira (basic auth with Token, bearer token otherwise)
+	Token        string            `mapstructure:"token"`        // jira
+}
+
+// AlertEvent is a change of an alert between two runs: new, reopened or resolved
+type AlertEvent struct {
+	Event      string        `json:"event"`
+	Alert      SecurityAlert `json:"alert"`
+	DetectedAt string        `json:"detected_at,omitempty"` // set by watch
+}
+
+// AlertSnapshot records the alerts seen by a previous run (key: repository:type:number, value: open or resolved)
+// and the events a notifier failed to receive (key: notifier name), sent to it again on the next run
+type AlertSnapshot struct {
+	Target    string                  `json:"target"`
+	UpdatedAt string                  `json:"updated_at"`
+	Alerts    map[string]string       `json:"alerts"`
+	Pending   map[string][]AlertEvent `json:"pending,omitempty"`
+}
@@ -9,6 +9,7 @@ type SecurityAndAnalysisReq struct {
 	AdvancedSecurity                  *StatusReq `json:"advanced_security,omitempty"`
 	SecretScanning                    *StatusReq `json:"secret_scanning,omitempty"`
 	SecretScanningNonProviderPatterns *StatusReq `json:"secret_scanning_non_provider_patterns,omitempty"`
+	SecretScanningValidityChecks      *StatusReq `json:"secret_scanning_validity_checks,omitempty"`
 	PushProtection                    *StatusReq `json:"secret_scanning_push_protection,omitempty"`
 	DependabotSecurityUpdates         *StatusReq `json:"dependabot_security_updates,omitempty"`
 }
@@ -16,14 +17,29 @@ type SecurityAndAnalysisReq struct {
 // OrgUpdateRequest mapeia para o corpo do PATCH /orgs/{org}
 // Usamos *bool para diferenciar "false" (desativar) de "nil" (ignorar)
 type OrgUpdateRequest struct {
-	AdvancedSecurityEnabledForNewRepos             *bool `json:"advanced_security_enabled_for_new_repositories,omitempty"`
-	SecretScanningEnabledForNewRepos               *bool `json:"secret_scanning_enabled_for_new_repositories,omitempty"`
-	SecretScanningPushProtectionEnabledForNewRepos *bool `json:"secret_scanning_push_protection_enabled_for_new_repositories,omitempty"`
-	DependabotAlertsEnabledForNewRepos             *bool `json:"dependabot_alerts_enabled_for_new_repositories,omitempty"`
-	DependabotSecurityUpdatesEnabledForNewRepos    *bool `json:"dependabot_security_updates_enabled_for_new_repositories,omitempty"`
-	DependencyGraphEnabledForNewRepos              *bool `json:"dependency_graph_enabled_for_new_repositories,omitempty"`
+	AdvancedSecurityEnabledForNewRepos             *bool   `json:"advanced_security_enabled_for_new_repositories,omitempty"`
+	SecretScanningEnabledForNewRepos               *bool   `json:"secret_scanning_enabled_for_new_repositories,omitempty"`
+	SecretScanningPushProtectionEnabledForNewRepos *bool   `json:"secret_scanning_push_protection_enabled_for_new_repositories,omitempty"`
+	DependabotAlertsEnabledForNewRepos             *bool   `json:"dependabot_alerts_enabled_for_new_repositories,omitempty"`
+	DependabotSecurityUpdatesEnabledForNewRepos    *bool   `json:"dependabot_security_updates_enabled_for_new_repositories,omitempty"`
+	DependencyGraphEnabledForNewRepos              *bool   `json:"dependency_graph_enabled_for_new_repositories,omitempty"`
+	SecretScanningPushProtectionCustomLink         *string `json:"secret_scanning_push_protection_custom_link,omitempty"`
+	SecretScanningPushProtectionCustomLinkEnabled  *bool   `json:"secret_scanning_push_protection_custom_link_enabled,omitempty"`
+}
+
+// CodeSecurityConfigurationUpdateRequest maps to the PATCH /orgs/{org}/code-security/configurations/{configuration_id} body
+type CodeSecurityConfigurationUpdateRequest struct {
+	SecretScanningDelegatedBypass        *string                 `json:"secret_scanning_delegated_bypass,omitempty"`
+	SecretScanningDelegatedBypassOptions *DelegatedBypassOptions `json:"secret_scanning_delegated_bypass_options,omitempty"`
 }
 
 type StatusReq struct {
 	Status string `json:"status"`
 }
+
+// BypassReviewRequest maps to the PATCH /repos/{owner}/{repo}/bypass-requests/secret-scanning/{number} body
+// Status must be "approve" or "deny"
+type BypassReviewRequest struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
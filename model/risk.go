@@ -0,0 +1,34 @@
+package model
+
+// RiskWeights configures the repository risk score ("risk" section of the config file)
+type RiskWeights struct {
+	Severity       map[string]float64            `mapstructure:"severity"`        // points per open alert, by severity
+	ActiveSecret   float64                       `mapstructure:"active_secret"`   // extra points per secret still valid
+	EPSS           float64                       `mapstructure:"epss"`            // extra points per Dependabot alert, times its EPSS percentile
+	MissingFeature float64                       `mapstructure:"missing_feature"` // points per disabled coverage feature
+	Visibility     map[string]float64            `mapstructure:"visibility"`      // score multiplier by visibility
+	Properties     map[string]map[string]float64 `mapstructure:"properties"`      // score multiplier by custom property value
+}
+
+// RepositoryRisk is the risk score of a repository with the data it was computed from
+type RepositoryRisk struct {
+	Repository      string              `json:"repository"`
+	Score           float64             `json:"score"`
+	Visibility      string              `json:"visibility"`
+	Alerts          map[string]int      `json:"alerts"` // open alerts by severity
+	ActiveSecrets   int                 `json:"active_secrets"`
+	MaxEPSS         float64             `json:"max_epss"`
+	MissingFeatures []string            `json:"missing_features"`
+	Properties      map[string][]string `json:"properties,omitempty"`
+	Breakdown       RiskBreakdown       `json:"breakdown"`
+}
+
+// RiskBreakdown details a score: (Alerts + Secrets + EPSS + Coverage) * VisibilityFactor * PropertyFactor
+type RiskBreakdown struct {
+	Alerts           float64 `json:"alerts"`
+	Secrets          float64 `json:"secrets"`
+	EPSS             float64 `json:"epss"`
+	Coverage         float64 `json:"coverage"`
+	VisibilityFactor float64 `json:"visibility_factor"`
+	PropertyFactor   float64 `json:"property_factor"`
+}
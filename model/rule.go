@@ -1,9 +1,13 @@
 package model
 
 type Rule struct {
-  Id          string
-  Severity    string
-  Tags        []Tag
-  Description string
-  Name        string
+  Id                    string
+  Severity              string
+  SecuritySeverityLevel string `json:"security_severity_level"`
+  Tags                  []Tag
+  Description           string
+  FullDescription       string `json:"full_description"`
+  Name                  string
+  Help                  string
+  HelpUri               string `json:"help_uri"`
 }
@@ -0,0 +1,70 @@
+package model
+
+import "encoding/json"
+
+// SBOMResponse maps to GET /repos/{owner}/{repo}/dependency-graph/sbom
+// SBOM keeps the raw SPDX document so it can be exported without losing fields.
+type SBOMResponse struct {
+	SBOM json.RawMessage `json:"sbom"`
+}
+
+// SPDXDocument is the subset of an SPDX 2.3 JSON document returned by GitHub
+type SPDXDocument struct {
+	SPDXID            string             `json:"SPDXID"`
+	SPDXVersion       string             `json:"spdxVersion"`
+	CreationInfo      SPDXCreationInfo   `json:"creationInfo"`
+	Name              string             `json:"name"`
+	DataLicense       string             `json:"dataLicense"`
+	DocumentDescribes []string           `json:"documentDescribes"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	Packages          []SPDXPackage      `json:"packages"`
+	Relationships     []SPDXRelationship `json:"relationships"`
+}
+
+type SPDXCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SPDXPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	Supplier         string            `json:"supplier"`
+	ExternalRefs     []SPDXExternalRef `json:"externalRefs"`
+}
+
+type SPDXExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceLocator  string `json:"referenceLocator"`
+	ReferenceType     string `json:"referenceType"`
+}
+
+type SPDXRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+	RelationshipType   string `json:"relationshipType"`
+}
+
+// SBOMManifestEntry describes one file of an organization SBOM bundle
+type SBOMManifestEntry struct {
+	Repository string `json:"repository"`
+	File       string `json:"file,omitempty"`
+	Format     string `json:"format"`
+	Packages   int    `json:"packages"`
+	SHA256     string `json:"sha256,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// SBOMManifest is the index written next to the SBOMs of an organization bundle
+type SBOMManifest struct {
+	Organization string              `json:"organization"`
+	Format       string              `json:"format"`
+	GeneratedAt  string              `json:"generated_at"`
+	Entries      []SBOMManifestEntry `json:"entries"`
+}
@@ -1,19 +1,57 @@
 package model
 
 type SecretScanningAlert struct {
-	Number                   int    `json:"number"`
-	CreatedAt                string `json:"created_at"`
-	UpdatedAt                string `json:"updated_at"`
-	Url                      string `json:"url"`
-	HtmlUrl                  string `json:"html_url"`
-	State                    string `json:"state"`
-	SecretType               string `json:"secret_type"`
-	SecretTypeDisplayName    string `json:"secret_type_display_name"`
-	Secret                   string `json:"secret"`
-	Resolution               string `json:"resolution"`
-	ResolvedBy               User   `json:"resolved_by"`
-	ResolvedAt               string `json:"resolved_at"`
-	PushProtectionBypassed   bool   `json:"push_protection_bypassed"`
-	PushProtectionBypassedBy User   `json:"push_protection_bypassed_by"`
-	PushProtectionBypassedAt string `json:"push_protection_bypassed_at"`
+	Number                   int                            `json:"number"`
+	CreatedAt                string                         `json:"created_at"`
+	UpdatedAt                string                         `json:"updated_at"`
+	Url                      string                         `json:"url"`
+	HtmlUrl                  string                         `json:"html_url"`
+	State                    string                         `json:"state"`
+	SecretType               string                         `json:"secret_type"`
+	SecretTypeDisplayName    string                         `json:"secret_type_display_name"`
+	Secret                   string                         `json:"secret"`
+	Resolution               string                         `json:"resolution"`
+	Validity                 string                         `json:"validity"` // active, inactive or unknown
+	ResolvedBy               User                           `json:"resolved_by"`
+	ResolvedAt               string                         `json:"resolved_at"`
+	PushProtectionBypassed   bool                           `json:"push_protection_bypassed"`
+	PushProtectionBypassedBy User                           `json:"push_protection_bypassed_by"`
+	PushProtectionBypassedAt string                         `json:"push_protection_bypassed_at"`
+	Locations                []SecretScanningLocation       `json:"locations,omitempty"`
+	FirstLocationDetected    *SecretScanningLocationDetails `json:"first_location_detected,omitempty"`
+	Repository               Repository                     `json:"repository"`       // only on organization level listings
+	Owners                   []string                       `json:"owners,omitempty"` // resolved by the ownership resolver, not part of the API
+}
+
+// SecretScanningLocation maps to GET /repos/{owner}/{repo}/secret-scanning/alerts/{alert_number}/locations
+// Type is one of commit, wiki_commit, issue_title, issue_body, issue_comment, discussion_title,
+// discussion_body, discussion_comment, pull_request_title, pull_request_body, pull_request_comment,
+// pull_request_review or pull_request_review_comment. Only the fields of that type are filled in Details.
+type SecretScanningLocation struct {
+	Type    string                        `json:"type"`
+	Details SecretScanningLocationDetails `json:"details"`
+}
+
+type SecretScanningLocationDetails struct {
+	Path                        string `json:"path,omitempty"`
+	StartLine                   int    `json:"start_line,omitempty"`
+	EndLine                     int    `json:"end_line,omitempty"`
+	StartColumn                 int    `json:"start_column,omitempty"`
+	EndColumn                   int    `json:"end_column,omitempty"`
+	BlobSha                     string `json:"blob_sha,omitempty"`
+	BlobUrl                     string `json:"blob_url,omitempty"`
+	CommitSha                   string `json:"commit_sha,omitempty"`
+	CommitUrl                   string `json:"commit_url,omitempty"`
+	PageUrl                     string `json:"page_url,omitempty"`
+	IssueTitleUrl               string `json:"issue_title_url,omitempty"`
+	IssueBodyUrl                string `json:"issue_body_url,omitempty"`
+	IssueCommentUrl             string `json:"issue_comment_url,omitempty"`
+	DiscussionTitleUrl          string `json:"discussion_title_url,omitempty"`
+	DiscussionBodyUrl           string `json:"discussion_body_url,omitempty"`
+	DiscussionCommentUrl        string `json:"discussion_comment_url,omitempty"`
+	PullRequestTitleUrl         string `json:"pull_request_title_url,omitempty"`
+	PullRequestBodyUrl          string `json:"pull_request_body_url,omitempty"`
+	PullRequestCommentUrl       string `json:"pull_request_comment_url,omitempty"`
+	PullRequestReviewUrl        string `json:"pull_request_review_url,omitempty"`
+	PullRequestReviewCommentUrl string `json:"pull_request_review_comment_url,omitempty"`
 }
@@ -0,0 +1,36 @@
+package model
+
+// SecurityAlert is a common view of code scanning, secret scanning and Dependabot alerts,
+// used by the commands working across alert types
+type SecurityAlert struct {
+	Repository string  `json:"repository"`
+	Type       string  `json:"type"`
+	Number     int     `json:"number"`
+	State      string  `json:"state"`
+	Severity   string  `json:"severity"`
+	Rule       string  `json:"rule"`
+	Title      string  `json:"title"`
+	Path       string  `json:"path,omitempty"`
+	Line       int     `json:"line,omitempty"`
+	URL        string  `json:"url"`
+	CreatedAt  string  `json:"created_at"`
+	Validity   string  `json:"validity,omitempty"` // secret scanning: active, inactive or unknown
+	EPSS       float64 `json:"epss,omitempty"`     // Dependabot: EPSS percentile (0-1)
+}
+
+// Issue maps to the issues of the REST API
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	HtmlUrl string `json:"html_url"`
+}
+
+// CreateIssueRequest is the body of POST /repos/{owner}/{repo}/issues
+type CreateIssueRequest struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+}
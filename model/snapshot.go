@@ -0,0 +1,52 @@
+package model
+
+// DependencySnapshot is the body of POST /repos/{owner}/{repo}/dependency-graph/snapshots
+type DependencySnapshot struct {
+	Version   int                          `json:"version"`
+	Sha       string                       `json:"sha"`
+	Ref       string                       `json:"ref"`
+	Job       SnapshotJob                  `json:"job"`
+	Detector  SnapshotDetector             `json:"detector"`
+	Scanned   string                       `json:"scanned"`
+	Metadata  map[string]interface{}       `json:"metadata,omitempty"`
+	Manifests map[string]*SnapshotManifest `json:"manifests,omitempty"`
+}
+
+type SnapshotJob struct {
+	Correlator string `json:"correlator"`
+	ID         string `json:"id"`
+	HtmlUrl    string `json:"html_url,omitempty"`
+}
+
+type SnapshotDetector struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+type SnapshotManifest struct {
+	Name     string                         `json:"name"`
+	File     *SnapshotManifestFile          `json:"file,omitempty"`
+	Metadata map[string]interface{}         `json:"metadata,omitempty"`
+	Resolved map[string]*SnapshotDependency `json:"resolved,omitempty"`
+}
+
+type SnapshotManifestFile struct {
+	SourceLocation string `json:"source_location"`
+}
+
+type SnapshotDependency struct {
+	PackageURL   string                 `json:"package_url"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Relationship string                 `json:"relationship,omitempty"`
+	Scope        string                 `json:"scope,omitempty"`
+	Dependencies []string               `json:"dependencies,omitempty"`
+}
+
+// SnapshotResult is the response of a snapshot submission
+type SnapshotResult struct {
+	ID        int    `json:"id"`
+	CreatedAt string `json:"created_at"`
+	Result    string `json:"result"`
+	Message   string `json:"message"`
+}
@@ -0,0 +1,24 @@
+package model
+
+type Team struct {
+	ID          int
+	NodeID      string `json:"node_id"`
+	Name        string
+	Slug        string
+	Description string
+	Privacy     string
+	Permission  string
+	URL         string
+	HtmlUrl     string `json:"html_url"`
+}
+
+type OrganizationRole struct {
+	ID          int
+	Name        string
+	Description string
+}
+
+type OrganizationRoles struct {
+	TotalCount int                `json:"total_count"`
+	Roles      []OrganizationRole `json:"roles"`
+}
@@ -1,7 +1,14 @@
 package model
 
 type UpdateAlert struct {
-	State            string
-	DismissedReason  string `json:"dismissed_reason",omitempty`
-	DismissedComment string `json:"dismissed_comment",omitempty`
+	State            string `json:"state"`
+	DismissedReason  string `json:"dismissed_reason,omitempty"`
+	DismissedComment string `json:"dismissed_comment,omitempty"`
+}
+
+// UpdateSecretScanningAlert maps to PATCH /repos/{owner}/{repo}/secret-scanning/alerts/{alert_number}
+type UpdateSecretScanningAlert struct {
+	State             string `json:"state"`
+	Resolution        string `json:"resolution,omitempty"`
+	ResolutionComment string `json:"resolution_comment,omitempty"`
 }
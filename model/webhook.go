@@ -0,0 +1,13 @@
+package model
+
+import "encoding/json"
+
+// WebhookPayload holds the fields shared by the repository and security alert webhook events.
+// The alert is decoded according to the event type.
+type WebhookPayload struct {
+	Action       string          `json:"action"`
+	Alert        json.RawMessage `json:"alert"`
+	Repository   Repository      `json:"repository"`
+	Organization Organization    `json:"organization"`
+	Sender       User            `json:"sender"`
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// RequestAutofix asks Copilot Autofix to generate a fix for a code scanning alert
+// Docs: POST /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}/autofix
+func (a *AlertServices) RequestAutofix(owner, repo string, number int) (*model.Autofix, error) {
+	autofix := &model.Autofix{}
+	path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d/autofix", owner, repo, number)
+	if err := client.Post(path, nil, autofix); err != nil {
+		return nil, err
+	}
+	return autofix, nil
+}
+
+// GetAutofix returns the status and description of the autofix of a code scanning alert
+// Docs: GET /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}/autofix
+func (a *AlertServices) GetAutofix(owner, repo string, number int) (*model.Autofix, error) {
+	autofix := &model.Autofix{}
+	path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d/autofix", owner, repo, number)
+	if err := client.Get(path, autofix); err != nil {
+		return nil, err
+	}
+	return autofix, nil
+}
+
+// AutofixStatuses requests (when request is set) or reads the autofix of several alerts of a repository
+func (a *AlertServices) AutofixStatuses(owner, repo string, numbers []int, request bool, jsonOutput bool) error {
+	statuses := make([]model.AutofixStatus, len(numbers))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, 5)
+	for i, number := range numbers {
+		wg.Add(1)
+		go func(index, number int) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			status := model.AutofixStatus{Alert: number}
+			if alert, err := a.GetCodeScanningAlert(owner, repo, number); err == nil {
+				status.Rule = alert.Rule.Id
+				status.Path = fmt.Sprintf("%s:%d", alert.MostRecentInstance.Location.Path, alert.MostRecentInstance.Location.StartLine)
+			}
+
+			var autofix *model.Autofix
+			var err error
+			if request {
+				autofix, err = a.RequestAutofix(owner, repo, number)
+			} else {
+				autofix, err = a.GetAutofix(owner, repo, number)
+			}
+			if err != nil {
+				status.Error = err.Error()
+			} else {
+				status.Autofix = *autofix
+			}
+			statuses[index] = status
+		}(i, number)
+	}
+	wg.Wait()
+
+	if jsonOutput {
+		return jsonLister(statuses)
+	}
+
+	tp, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+	tp.AddHeader([]string{"Alert", "Rule", "Location", "Status", "Started At", "Description"})
+	for _, s := range statuses {
+		status, description := s.Status, s.Description
+		if s.Error != "" {
+			status, description = "error", s.Error
+		}
+		tp.AddField(fmt.Sprintf("#%d", s.Alert))
+		tp.AddField(s.Rule)
+		tp.AddField(s.Path)
+		tp.AddField(status)
+		tp.AddField(s.StartedAt)
+		tp.AddField(description)
+		tp.EndRow()
+	}
+	return tp.Render()
+}
+
+// ShowAutofix renders the alert and the full description of its suggested fix
+func (a *AlertServices) ShowAutofix(owner, repo string, number int, jsonOutput bool) error {
+	alert, err := a.GetCodeScanningAlert(owner, repo, number)
+	if err != nil {
+		return err
+	}
+	autofix, err := a.GetAutofix(owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return jsonLister(autofix)
+	}
+
+	location := alert.MostRecentInstance.Location
+	fmt.Printf("Alert #%d: %s (%s)\n", alert.Numer, alert.Rule.Description, alert.Rule.Id)
+	fmt.Printf("Location: %s:%d-%d\n", location.Path, location.StartLine, location.EndLine)
+	fmt.Printf("Autofix: %s (started at %s)\n\n", autofix.Status, autofix.StartedAt)
+	fmt.Println(autofix.Description)
+	if autofix.Status == "success" {
+		fmt.Printf("\nCommit it to a branch to review the changes: gh advanced-security autofix commit %s/%s %d --branch <name>\n", owner, repo, number)
+	}
+	return nil
+}
+
+// CommitAutofixes commits the suggested fix of each alert to a new branch created from the branch
+// the first alert was found on, prints the resulting diff and optionally opens a pull request.
+// Docs: POST /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}/autofix/commits
+func (a *AlertServices) CommitAutofixes(owner, repo string, numbers []int, branch string, openPR bool) error {
+	alert, err := a.GetCodeScanningAlert(owner, repo, numbers[0])
+	if err != nil {
+		return err
+	}
+	base := strings.TrimPrefix(alert.MostRecentInstance.Ref, "refs/heads/")
+	if strings.HasPrefix(base, "refs/") {
+		return fmt.Errorf("alert #%d was found on %s, not on a branch", alert.Numer, alert.MostRecentInstance.Ref)
+	}
+
+	// The autofix commits endpoint needs an existing branch
+	baseRef := &model.GitRef{}
+	if err := client.Get(fmt.Sprintf("repos/%s/%s/git/ref/heads/%s", owner, repo, base), baseRef); err != nil {
+		return err
+	}
+	newRef := model.CreateRefRequest{Ref: "refs/heads/" + branch, Sha: baseRef.Object.Sha}
+	if err := post(fmt.Sprintf("repos/%s/%s/git/refs", owner, repo), newRef, nil); err != nil {
+		return fmt.Errorf("unable to create branch '%s': %w", branch, err)
+	}
+	fmt.Printf("Created branch %s from %s\n", branch, base)
+
+	// Don't leave an empty branch behind
+	// Docs: DELETE /repos/{owner}/{repo}/git/refs/{ref}
+	deleteBranch := func(reason string) error {
+		if err := client.Delete(fmt.Sprintf("repos/%s/%s/git/refs/heads/%s", owner, repo, branch), nil); err != nil {
+			return fmt.Errorf("%s, and branch '%s' could not be deleted: %w", reason, branch, err)
+		}
+		fmt.Printf("Deleted branch %s\n", branch)
+		return errors.New(reason)
+	}
+
+	committed := []string{}
+	for _, number := range numbers {
+		request := model.AutofixCommitRequest{
+			TargetRef: "refs/heads/" + branch,
+			Message:   fmt.Sprintf("Apply autofix for code scanning alert #%d", number),
+		}
+		response := &model.AutofixCommitResponse{}
+		path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d/autofix/commits", owner, repo, number)
+		if err := post(path, request, response); err != nil {
+			fmt.Printf("- #%d: %s\n", number, err)
+			continue
+		}
+		fmt.Printf("- #%d: committed %s\n", number, shortSha(response.Sha))
+		committed = append(committed, fmt.Sprintf("#%d", number))
+	}
+	if len(committed) == 0 {
+		return deleteBranch("no autofix could be committed")
+	}
+
+	comparison := &model.Comparison{}
+	if err := client.Get(fmt.Sprintf("repos/%s/%s/compare/%s...%s", owner, repo, base, branch), comparison); err != nil {
+		// The branch has the commits: keep it for a manual review
+		return fmt.Errorf("autofixes committed to branch '%s', but unable to compare it with %s: %w", branch, base, err)
+	}
+	if len(comparison.Files) == 0 {
+		return deleteBranch("the committed autofixes don't change any file")
+	}
+	for _, f := range comparison.Files {
+		fmt.Printf("\n--- %s (%s)\n%s\n", f.Filename, f.Status, f.Patch)
+	}
+
+	if !openPR {
+		return nil
+	}
+
+	pr := &model.PullRequest{}
+	request := model.CreatePullRequest{
+		Title: fmt.Sprintf("Fix code scanning alerts %s", strings.Join(committed, ", ")),
+		Head:  branch,
+		Base:  base,
+		Body:  fmt.Sprintf("Copilot Autofix suggestions for the code scanning alerts %s.", strings.Join(committed, ", ")),
+	}
+	if err := post(fmt.Sprintf("repos/%s/%s/pulls", owner, repo), request, pr); err != nil {
+		return err
+	}
+	fmt.Printf("\nPull request #%d opened: %s\n", pr.Number, pr.HtmlUrl)
+	return nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// BypassRequestFilter narrows the delegated bypass requests returned by ListBypassRequests.
+// Requester and Status are sent to the API. The API has no secret type nor ruleset parameter, so
+// SecretType and Ruleset (the name or id of the ruleset of a data entry) are matched locally,
+// on every request before paging.
+type BypassRequestFilter struct {
+	Requester  string
+	Status     string
+	SecretType string
+	Ruleset    string
+}
+
+// local tells whether the filter has criteria matched locally
+func (f BypassRequestFilter) local() bool {
+	return f.SecretType != "" || f.Ruleset != ""
+}
+
+// ListBypassRequests fetches delegated bypass requests for a repository,
+// or for the whole organization when repo is empty.
+// Docs: GET /orgs/{org}/bypass-requests/secret-scanning (org) and GET /repos/{owner}/{repo}/bypass-requests/secret-scanning (repo)
+func (a *AlertServices) ListBypassRequests(owner, repo string, filter BypassRequestFilter, jsonOutput bool, userPageSize int, fetchAll bool) error {
+	pageSize := GetOptimalPageSize(userPageSize)
+
+	query := url.Values{}
+	query.Set("per_page", fmt.Sprintf("%d", pageSize))
+	if filter.Requester != "" {
+		query.Set("requester", filter.Requester)
+	}
+	if filter.Status != "" {
+		query.Set("request_status", filter.Status)
+	}
+	if filter.local() {
+		// Everything is fetched before filtering: use the largest pages
+		query.Set("per_page", "100")
+	}
+
+	path := fmt.Sprintf("orgs/%s/bypass-requests/secret-scanning?%s", owner, query.Encode())
+	if repo != "" {
+		path = fmt.Sprintf("repos/%s/%s/bypass-requests/secret-scanning?%s", owner, repo, query.Encode())
+	}
+
+	if filter.local() {
+		return a.listFilteredBypassRequests(path, filter, jsonOutput, pageSize, fetchAll)
+	}
+
+	var allRequests []model.BypassRequest
+
+	for {
+		var pageRequests []model.BypassRequest
+		nextUrl, err := getPages(path, &pageRequests)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			allRequests = append(allRequests, pageRequests...)
+			if nextUrl == "" {
+				break
+			}
+			path = nextUrl
+			continue
+		}
+
+		if err := a.printBypassRequestTable(pageRequests); err != nil {
+			return err
+		}
+
+		if nextUrl == "" {
+			break
+		}
+
+		if !fetchAll {
+			if !AskForNextPage() {
+				break
+			}
+		}
+		path = nextUrl
+	}
+
+	if jsonOutput {
+		return jsonLister(allRequests)
+	}
+	return nil
+}
+
+// listFilteredBypassRequests fetches every request, keeps the ones matching the local criteria
+// and pages the result, so a page is never emptied by the filter
+func (a *AlertServices) listFilteredBypassRequests(path string, filter BypassRequestFilter, jsonOutput bool, pageSize int, fetchAll bool) error {
+	requests, err := fetchAllPages[model.BypassRequest](path)
+	if err != nil {
+		return err
+	}
+	requests = filter.apply(requests)
+
+	if jsonOutput {
+		return jsonLister(requests)
+	}
+
+	for start := 0; ; start += pageSize {
+		end := min(start+pageSize, len(requests))
+		if err := a.printBypassRequestTable(requests[start:end]); err != nil {
+			return err
+		}
+		if end == len(requests) {
+			return nil
+		}
+		if !fetchAll && !AskForNextPage() {
+			return nil
+		}
+	}
+}
+
+// ReviewBypassRequest approves or denies a delegated bypass request with a reviewer comment.
+// status must be "approve" or "deny".
+// Docs: PATCH /repos/{owner}/{repo}/bypass-requests/secret-scanning/{bypass_request_number}
+func (a *AlertServices) ReviewBypassRequest(owner, repo string, number int, status, comment string) error {
+	if status != "approve" && status != "deny" {
+		return fmt.Errorf("invalid review status '%s' (expected approve or deny)", status)
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/bypass-requests/secret-scanning/%d", owner, repo, number)
+	payload := model.BypassReviewRequest{
+		Status:  status,
+		Message: comment,
+	}
+	return patch(path, payload)
+}
+
+// apply keeps only the requests matching the local (secret type and ruleset) criteria.
+// A request matches when any of its data entries matches.
+func (f BypassRequestFilter) apply(requests []model.BypassRequest) []model.BypassRequest {
+	if !f.local() {
+		return requests
+	}
+
+	filtered := []model.BypassRequest{}
+	for _, r := range requests {
+		for _, d := range r.Data {
+			if f.matches(d) {
+				filtered = append(filtered, r)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+func (f BypassRequestFilter) matches(d model.BypassRequestData) bool {
+	if f.SecretType != "" && !strings.EqualFold(d.SecretType, f.SecretType) {
+		return false
+	}
+	if f.Ruleset != "" && !strings.EqualFold(d.RulesetName, f.Ruleset) && (d.RulesetID == 0 || f.Ruleset != fmt.Sprint(d.RulesetID)) {
+		return false
+	}
+	return true
+}
+
+// Helper for Bypass Requests
+func (a *AlertServices) printBypassRequestTable(requests []model.BypassRequest) error {
+	tp, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	tp.AddHeader([]string{"Number", "Repository", "Secret Type", "Status", "Requester", "Comment", "Expires At", "Created At"})
+	for _, r := range requests {
+		secretTypes := []string{}
+		for _, d := range r.Data {
+			secretTypes = append(secretTypes, d.SecretType)
+		}
+
+		tp.AddField(fmt.Sprintf("%d", r.Number))
+		tp.AddField(r.Repository.FullName)
+		tp.AddField(strings.Join(secretTypes, ","))
+		tp.AddField(r.Status)
+		tp.AddField(r.Requester.ActorName)
+
+		comment := r.RequesterComment
+		if len(comment) > 40 {
+			comment = comment[:37] + "..."
+		}
+		tp.AddField(comment)
+		tp.AddField(r.ExpiresAt)
+		tp.AddField(r.CreatedAt)
+		tp.EndRow()
+	}
+	return tp.Render()
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// Alert events
+const (
+	AlertEventNew      = "new"
+	AlertEventReopened = "reopened"
+	AlertEventResolved = "resolved"
+)
+
+var (
+	unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	alertKeyPattern = regexp.MustCompile(`^(.+):([a-z-]+):(\d+)$`)
+)
+
+// AlertKey identifies an alert across runs
+func AlertKey(alert model.SecurityAlert) string {
+	return fmt.Sprintf("%s:%s:%d", alert.Repository, alert.Type, alert.Number)
+}
+
+// DefaultSnapshotFile returns where the alerts seen for a target are recorded between runs
+func DefaultSnapshotFile(target string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gh-advanced-security", "alerts-"+unsafeFileChars.ReplaceAllString(target, "_")+".json")
+}
+
+// LoadAlertSnapshot reads the alerts recorded by the previous run (nil when there was none)
+func LoadAlertSnapshot(file string) (*model.AlertSnapshot, error) {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &model.AlertSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("invalid alert snapshot %s: %w", file, err)
+	}
+	return snapshot, nil
+}
+
+// SaveAlertSnapshot records the alerts for the next run
+func SaveAlertSnapshot(file string, snapshot *model.AlertSnapshot) error {
+	snapshot.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+// DiffAlerts compares the open alerts with the previous snapshot and returns the events and the
+// new snapshot. Resolved alerts are kept in the snapshot, so they are reported as reopened later.
+func DiffAlerts(target string, previous *model.AlertSnapshot, open []model.SecurityAlert) ([]model.AlertEvent, *model.AlertSnapshot) {
+	known := map[string]string{}
+	if previous != nil {
+		known = previous.Alerts
+	}
+
+	next := &model.AlertSnapshot{Target: target, Alerts: map[string]string{}}
+	events := []model.AlertEvent{}
+	current := map[string]model.SecurityAlert{}
+
+	for _, alert := range open {
+		key := AlertKey(alert)
+		current[key] = alert
+		next.Alerts[key] = "open"
+
+		switch known[key] {
+		case "":
+			events = append(events, model.AlertEvent{Event: AlertEventNew, Alert: alert})
+		case "resolved":
+			events = append(events, model.AlertEvent{Event: AlertEventReopened, Alert: alert})
+		}
+	}
+
+	for key, state := range known {
+		if _, stillOpen := current[key]; stillOpen {
+			continue
+		}
+		next.Alerts[key] = "resolved"
+		if state == "open" {
+			events = append(events, model.AlertEvent{Event: AlertEventResolved, Alert: alertFromKey(key)})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return AlertKey(events[i].Alert) < AlertKey(events[j].Alert)
+	})
+	return events, next
+}
+
+// alertFromKey rebuilds the identity of an alert that is no longer listed as open
+func alertFromKey(key string) model.SecurityAlert {
+	alert := model.SecurityAlert{State: "resolved"}
+	if match := alertKeyPattern.FindStringSubmatch(key); match != nil {
+		alert.Repository = match[1]
+		alert.Type = match[2]
+		alert.Number, _ = strconv.Atoi(match[3])
+		alert.URL = fmt.Sprintf("https://github.com/%s/security/%s/%d", alert.Repository, alert.Type, alert.Number)
+	}
+	return alert
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+func TestDiffAlerts(t *testing.T) {
+	alert := func(repository, kind string, number int) model.SecurityAlert {
+		return model.SecurityAlert{Repository: repository, Type: kind, Number: number, State: "open"}
+	}
+	a1 := alert("org/api", "code-scanning", 1)
+	a2 := alert("org/api", "dependabot", 2)
+	a3 := alert("org/web", "secret-scanning", 3)
+
+	tests := []struct {
+		name     string
+		previous *model.AlertSnapshot
+		open     []model.SecurityAlert
+		events   []string
+		alerts   map[string]string
+	}{
+		{
+			name:   "first run",
+			open:   []model.SecurityAlert{a2, a1},
+			events: []string{"new org/api:code-scanning:1", "new org/api:dependabot:2"},
+			alerts: map[string]string{"org/api:code-scanning:1": "open", "org/api:dependabot:2": "open"},
+		},
+		{
+			name:     "no changes",
+			previous: &model.AlertSnapshot{Alerts: map[string]string{"org/api:code-scanning:1": "open"}},
+			open:     []model.SecurityAlert{a1},
+			events:   []string{},
+			alerts:   map[string]string{"org/api:code-scanning:1": "open"},
+		},
+		{
+			name:     "new and resolved",
+			previous: &model.AlertSnapshot{Alerts: map[string]string{"org/api:code-scanning:1": "open", "org/api:dependabot:2": "open"}},
+			open:     []model.SecurityAlert{a1, a3},
+			events:   []string{"resolved org/api:dependabot:2", "new org/web:secret-scanning:3"},
+			alerts:   map[string]string{"org/api:code-scanning:1": "open", "org/api:dependabot:2": "resolved", "org/web:secret-scanning:3": "open"},
+		},
+		{
+			name:     "reopened",
+			previous: &model.AlertSnapshot{Alerts: map[string]string{"org/api:code-scanning:1": "resolved"}},
+			open:     []model.SecurityAlert{a1},
+			events:   []string{"reopened org/api:code-scanning:1"},
+			alerts:   map[string]string{"org/api:code-scanning:1": "open"},
+		},
+		{
+			name:     "still resolved",
+			previous: &model.AlertSnapshot{Alerts: map[string]string{"org/api:code-scanning:1": "resolved"}},
+			open:     []model.SecurityAlert{},
+			events:   []string{},
+			alerts:   map[string]string{"org/api:code-scanning:1": "resolved"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, next := DiffAlerts("org", tt.previous, tt.open)
+
+			got := []string{}
+			for _, e := range events {
+				got = append(got, e.Event+" "+AlertKey(e.Alert))
+			}
+			if !slices.Equal(got, tt.events) {
+				t.Errorf("events = %v, want %v", got, tt.events)
+			}
+			if !maps.Equal(next.Alerts, tt.alerts) {
+				t.Errorf("snapshot = %v, want %v", next.Alerts, tt.alerts)
+			}
+		})
+	}
+}
+
+func TestAlertFromKey(t *testing.T) {
+	alert := alertFromKey("org/my-repo:secret-scanning:42")
+	if alert.Repository != "org/my-repo" || alert.Type != "secret-scanning" || alert.Number != 42 || alert.State != "resolved" {
+		t.Errorf("alertFromKey = %+v", alert)
+	}
+	if alert.URL != "https://github.com/org/my-repo/security/secret-scanning/42" {
+		t.Errorf("URL = %s", alert.URL)
+	}
+}
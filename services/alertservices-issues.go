@@ -0,0 +1,259 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// issueMarker is hidden in the body of every issue opened from alerts, so re-runs skip them
+var issueMarker = regexp.MustCompile(`<!-- ghas-alert: (\S+) -->`)
+
+// DefaultIssueTemplate is the body of the issues opened from alerts. It receives an IssueData.
+const DefaultIssueTemplate = `{{ len .Alerts }} open {{ .Type }} alert(s) in **{{ .Repository }}**{{ if .Rule }} for ` + "`{{ .Rule }}`" + `{{ end }}.
+
+| Alert | Severity | Title | Location |
+|---|---|---|---|
+{{ range .Alerts }}| [#{{ .Number }}]({{ .URL }}) | {{ .Severity }} | {{ .Title }} | {{ if .Path }}` + "`{{ .Path }}{{ if .Line }}:{{ .Line }}{{ end }}`" + `{{ end }} |
+{{ end }}
+{{ if .Owners }}Owners (CODEOWNERS): {{ join .Owners ", " }}
+{{ end }}`
+
+// IssueOptions selects the alerts to turn into issues and how the issues are written
+type IssueOptions struct {
+	Types            []string
+	MinSeverity      string
+	RepoFilter       string // regular expression on the repository full name
+	GroupByRule      bool   // one issue per rule per repository instead of one per alert
+	Tracker          string // owner/repo receiving every issue, instead of the affected repository
+	TemplateFile     string
+	Labels           []string
+	AssignCodeowners bool
+	DryRun           bool
+}
+
+// IssueData is passed to the issue template
+type IssueData struct {
+	Repository string
+	Type       string
+	Rule       string
+	Alerts     []model.SecurityAlert
+	Owners     []string
+}
+
+type alertIssue struct {
+	marker      string
+	destination string
+	title       string
+	data        IssueData
+}
+
+// CreateIssuesFromAlerts opens an issue per open alert (or per rule per repository) of a repository or
+// organization, skipping the alerts whose issue was opened by a previous run. Returns the number of issues opened.
+func (a *AlertServices) CreateIssuesFromAlerts(target string, opts IssueOptions) (int, error) {
+	if opts.MinSeverity != "" && AlertSeverityRank(opts.MinSeverity) < 0 {
+		return 0, fmt.Errorf("invalid severity '%s' (expected %s)", opts.MinSeverity, strings.Join(AlertSeverities, ", "))
+	}
+	repoFilter, err := regexp.Compile(opts.RepoFilter)
+	if err != nil {
+		return 0, fmt.Errorf("invalid repository filter: %w", err)
+	}
+
+	templateText := DefaultIssueTemplate
+	if opts.TemplateFile != "" {
+		content, err := os.ReadFile(opts.TemplateFile)
+		if err != nil {
+			return 0, err
+		}
+		templateText = string(content)
+	}
+	tmpl, err := template.New("issue").Funcs(template.FuncMap{"join": strings.Join}).Parse(templateText)
+	if err != nil {
+		return 0, fmt.Errorf("invalid issue template: %w", err)
+	}
+
+	alerts, err := a.FetchSecurityAlerts(target, opts.Types, "open")
+	if err != nil {
+		return 0, err
+	}
+
+	issues := groupAlertIssues(alerts, opts, repoFilter)
+	if len(issues) == 0 {
+		fmt.Println("No matching open alerts.")
+		return 0, nil
+	}
+
+	existing := map[string]map[string]int{}
+	codeowners := map[string]Codeowners{}
+	created := 0
+
+	for _, issue := range issues {
+		if _, done := existing[issue.destination]; !done {
+			owner, repo, _ := strings.Cut(issue.destination, "/")
+			markers, err := a.findIssueMarkers(owner, repo, opts.Labels)
+			if err != nil {
+				return created, err
+			}
+			existing[issue.destination] = markers
+		}
+		if number, found := existing[issue.destination][issue.marker]; found {
+			fmt.Printf("- %s: already tracked in %s#%d\n", issue.title, issue.destination, number)
+			continue
+		}
+
+		if opts.AssignCodeowners {
+			if _, done := codeowners[issue.data.Repository]; !done {
+				owner, repo, _ := strings.Cut(issue.data.Repository, "/")
+				// A repository without CODEOWNERS gives no owners, but a file that can't be read is an error
+				found, err := GetRepositoryServices().GetCodeowners(owner, repo)
+				if err != nil {
+					return created, fmt.Errorf("unable to read the CODEOWNERS of %s: %w", issue.data.Repository, err)
+				}
+				codeowners[issue.data.Repository] = found
+			}
+			issue.data.Owners = alertOwners(codeowners[issue.data.Repository], issue.data.Alerts)
+		}
+
+		var body bytes.Buffer
+		if err := tmpl.Execute(&body, issue.data); err != nil {
+			return created, err
+		}
+		fmt.Fprintf(&body, "\n<!-- ghas-alert: %s -->\n", issue.marker)
+
+		request := model.CreateIssueRequest{
+			Title:  issue.title,
+			Body:   body.String(),
+			Labels: opts.Labels,
+		}
+		// Teams and e-mails from CODEOWNERS can't be assigned, they are only mentioned in the body
+		for _, o := range issue.data.Owners {
+			if strings.HasPrefix(o, "@") && !strings.Contains(o, "/") {
+				request.Assignees = append(request.Assignees, strings.TrimPrefix(o, "@"))
+			}
+		}
+
+		if opts.DryRun {
+			fmt.Printf("- %s: would open in %s (assignees: %s)\n", issue.title, issue.destination, strings.Join(request.Assignees, ", "))
+			continue
+		}
+
+		opened, err := a.createIssue(issue.destination, request)
+		if err != nil {
+			fmt.Printf("- %s: %s\n", issue.title, err)
+			continue
+		}
+		existing[issue.destination][issue.marker] = opened.Number
+		created++
+		fmt.Printf("- %s: opened %s\n", issue.title, opened.HtmlUrl)
+	}
+	return created, nil
+}
+
+// groupAlertIssues filters the alerts and groups them into the issues to open
+func groupAlertIssues(alerts []model.SecurityAlert, opts IssueOptions, repoFilter *regexp.Regexp) []*alertIssue {
+	byMarker := map[string]*alertIssue{}
+	for _, alert := range alerts {
+		if AlertSeverityRank(alert.Severity) < AlertSeverityRank(opts.MinSeverity) || !repoFilter.MatchString(alert.Repository) {
+			continue
+		}
+
+		destination := alert.Repository
+		if opts.Tracker != "" {
+			destination = opts.Tracker
+		}
+
+		marker := fmt.Sprintf("%s:%s:%d", alert.Repository, alert.Type, alert.Number)
+		title := fmt.Sprintf("[%s] %s alert #%d: %s", alert.Severity, alert.Type, alert.Number, alert.Title)
+		rule := ""
+		if opts.GroupByRule {
+			rule = alert.Rule
+			marker = fmt.Sprintf("%s:%s:rule:%s", alert.Repository, alert.Type, alert.Rule)
+			title = fmt.Sprintf("%s alerts for %s", alert.Type, alert.Rule)
+		}
+		if opts.Tracker != "" {
+			title += " in " + alert.Repository
+		}
+
+		issue, ok := byMarker[marker]
+		if !ok {
+			issue = &alertIssue{
+				marker:      marker,
+				destination: destination,
+				title:       title,
+				data:        IssueData{Repository: alert.Repository, Type: alert.Type, Rule: rule},
+			}
+			byMarker[marker] = issue
+		}
+		issue.data.Alerts = append(issue.data.Alerts, alert)
+	}
+
+	issues := make([]*alertIssue, 0, len(byMarker))
+	for _, issue := range byMarker {
+		issues = append(issues, issue)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].marker < issues[j].marker })
+	return issues
+}
+
+// alertOwners returns the CODEOWNERS owners of the files of the alerts, without duplicates
+func alertOwners(codeowners Codeowners, alerts []model.SecurityAlert) []string {
+	seen := map[string]bool{}
+	owners := []string{}
+	for _, alert := range alerts {
+		if alert.Path == "" {
+			continue
+		}
+		for _, o := range codeowners.Owners(alert.Path) {
+			if !seen[o] {
+				seen[o] = true
+				owners = append(owners, o)
+			}
+		}
+	}
+	return owners
+}
+
+// findIssueMarkers maps the markers of the issues opened by previous runs to their issue number.
+// Only issues with the first label are read, when labels are given.
+func (a *AlertServices) findIssueMarkers(owner, repo string, labels []string) (map[string]int, error) {
+	query := url.Values{}
+	query.Set("state", "all")
+	query.Set("per_page", "100")
+	if len(labels) > 0 {
+		query.Set("labels", labels[0])
+	}
+
+	issues, err := fetchAllPages[model.Issue](fmt.Sprintf("repos/%s/%s/issues?%s", owner, repo, query.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	markers := map[string]int{}
+	for _, issue := range issues {
+		for _, match := range issueMarker.FindAllStringSubmatch(issue.Body, -1) {
+			markers[match[1]] = issue.Number
+		}
+	}
+	return markers, nil
+}
+
+// createIssue opens an issue, retrying without assignees when GitHub rejects them
+// (CODEOWNERS may list users that can't be assigned in the repository)
+// Docs: POST /repos/{owner}/{repo}/issues
+func (a *AlertServices) createIssue(repository string, request model.CreateIssueRequest) (*model.Issue, error) {
+	issue := &model.Issue{}
+	path := fmt.Sprintf("repos/%s/issues", repository)
+	err := post(path, request, issue)
+	if err != nil && len(request.Assignees) > 0 {
+		request.Assignees = nil
+		err = post(path, request, issue)
+	}
+	return issue, err
+}
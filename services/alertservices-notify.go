@@ -0,0 +1,82 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// NotifyOptions configures a notification run
+type NotifyOptions struct {
+	Types          []string
+	Notifiers      []string // names from the config file, all when empty
+	StateFile      string
+	NotifyExisting bool // on the first run, notify every open alert instead of only recording them
+	DryRun         bool // print the events without notifying nor recording them
+}
+
+// NotifyAlertChanges compares the open alerts of a repository or organization with the previous run
+// and sends the new, reopened and resolved alerts to the configured notifiers
+func (a *AlertServices) NotifyAlertChanges(target string, opts NotifyOptions, jsonOutput bool) error {
+	notifiers, err := LoadNotifiers(opts.Notifiers)
+	if err != nil {
+		return err
+	}
+
+	stateFile := opts.StateFile
+	if stateFile == "" {
+		stateFile = DefaultSnapshotFile(target)
+	}
+	previous, err := LoadAlertSnapshot(stateFile)
+	if err != nil {
+		return err
+	}
+
+	open, err := a.FetchSecurityAlerts(target, opts.Types, "open")
+	if err != nil {
+		return err
+	}
+	events, next := DiffAlerts(target, previous, open)
+
+	if previous == nil && !opts.NotifyExisting {
+		// First run: record the baseline, otherwise every open alert would be notified
+		events = []model.AlertEvent{}
+		fmt.Fprintf(os.Stderr, "First run for %s: %d open alerts recorded, changes will be notified from the next run\n", target, len(open))
+	}
+
+	if jsonOutput {
+		if err := jsonLister(events); err != nil {
+			return err
+		}
+	} else {
+		for _, e := range events {
+			fmt.Printf("- %s %s #%d [%s] %s\n", e.Event, e.Alert.Repository, e.Alert.Number, e.Alert.Severity, e.Alert.Title)
+		}
+	}
+
+	if opts.DryRun {
+		return nil
+	}
+
+	var pending map[string][]model.AlertEvent
+	if previous != nil {
+		pending = previous.Pending
+	}
+
+	var notifyErr error
+	if len(events) > 0 || len(pending) > 0 {
+		names := []string{}
+		for _, n := range notifiers {
+			names = append(names, n.Name())
+		}
+		fmt.Fprintf(os.Stderr, "Notifying %d changes to %s...\n", len(events), strings.Join(names, ", "))
+		// A failing notifier keeps its events in the snapshot, to receive them on the next run
+		next.Pending, notifyErr = NotifyPending(notifiers, pending, events)
+	}
+	if err := SaveAlertSnapshot(stateFile, next); err != nil {
+		return err
+	}
+	return notifyErr
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// GetSecretScanningAlert fetches a single Secret Scanning alert
+func (a *AlertServices) GetSecretScanningAlert(owner, repo string, number int) (*model.SecretScanningAlert, error) {
+	alert := &model.SecretScanningAlert{}
+	path := fmt.Sprintf("repos/%s/%s/secret-scanning/alerts/%d", owner, repo, number)
+	err := client.Get(path, alert)
+	return alert, err
+}
+
+// FetchSecretScanningLocations retrieves every place (commits, issues, PRs, discussions, wiki) where the secret was found
+// Docs: GET /repos/{owner}/{repo}/secret-scanning/alerts/{alert_number}/locations
+func (a *AlertServices) FetchSecretScanningLocations(owner, repo string, number int) ([]model.SecretScanningLocation, error) {
+	var allLocations []model.SecretScanningLocation
+	path := fmt.Sprintf("repos/%s/%s/secret-scanning/alerts/%d/locations?per_page=100", owner, repo, number)
+
+	for {
+		var pageLocations []model.SecretScanningLocation
+		nextUrl, err := getPages(path, &pageLocations)
+		if err != nil {
+			return nil, err
+		}
+		allLocations = append(allLocations, pageLocations...)
+		if nextUrl == "" {
+			break
+		}
+		path = nextUrl
+	}
+	return allLocations, nil
+}
+
+// ShowSecretScanningAlert renders a Secret Scanning alert with all of its locations
+func (a *AlertServices) ShowSecretScanningAlert(owner, repo string, number int, jsonOutput bool) error {
+	alert, err := a.GetSecretScanningAlert(owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	alert.Locations, err = a.FetchSecretScanningLocations(owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return jsonLister(alert)
+	}
+
+	tablePrinter, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	validity := alert.Validity
+	if validity == "" {
+		validity = "unknown"
+	}
+
+	tablePrinter.AddField("Alert")
+	tablePrinter.AddField(fmt.Sprintf("#%d", alert.Number))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Secret Type")
+	tablePrinter.AddField(alert.SecretTypeDisplayName)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("State")
+	tablePrinter.AddField(alert.State)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Validity")
+	tablePrinter.AddField(validity)
+	tablePrinter.EndRow()
+	if alert.Resolution != "" {
+		tablePrinter.AddField("Resolution")
+		tablePrinter.AddField(fmt.Sprintf("%s by %s at %s", alert.Resolution, alert.ResolvedBy.Login, alert.ResolvedAt))
+		tablePrinter.EndRow()
+	}
+	if alert.PushProtectionBypassed {
+		tablePrinter.AddField("Push Protection Bypassed")
+		tablePrinter.AddField(fmt.Sprintf("by %s at %s", alert.PushProtectionBypassedBy.Login, alert.PushProtectionBypassedAt))
+		tablePrinter.EndRow()
+	}
+	tablePrinter.AddField("Created At")
+	tablePrinter.AddField(alert.CreatedAt)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("URL")
+	tablePrinter.AddField(alert.HtmlUrl)
+	tablePrinter.EndRow()
+	if err := tablePrinter.Render(); err != nil {
+		return err
+	}
+
+	// The locations have more columns than the summary: they get their own table
+	fmt.Printf("\nLocations (%d):\n", len(alert.Locations))
+	if len(alert.Locations) == 0 {
+		return nil
+	}
+	locations, err := newTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	// Links to files are built from the alert URL (https://host/owner/repo/security/...)
+	repoUrl, _, _ := strings.Cut(alert.HtmlUrl, "/security/")
+
+	locations.AddHeader([]string{"Type", "Location", "Link"})
+	for _, l := range alert.Locations {
+		where, link := describeSecretLocation(repoUrl, l)
+		locations.AddField(l.Type)
+		locations.AddField(where)
+		locations.AddField(link)
+		locations.EndRow()
+	}
+	return locations.Render()
+}
+
+// describeSecretLocation returns a short description and a link for a secret location
+func describeSecretLocation(repoUrl string, l model.SecretScanningLocation) (string, string) {
+	d := l.Details
+	switch l.Type {
+	case "commit":
+		where := fmt.Sprintf("%s:%d-%d @ %s", d.Path, d.StartLine, d.EndLine, shortSha(d.CommitSha))
+		link := fmt.Sprintf("%s/blob/%s/%s#L%d-L%d", repoUrl, d.CommitSha, d.Path, d.StartLine, d.EndLine)
+		return where, link
+	case "wiki_commit":
+		return fmt.Sprintf("%s:%d-%d @ %s", d.Path, d.StartLine, d.EndLine, shortSha(d.CommitSha)), d.PageUrl
+	case "issue_title":
+		return "Issue title", d.IssueTitleUrl
+	case "issue_body":
+		return "Issue body", d.IssueBodyUrl
+	case "issue_comment":
+		return "Issue comment", d.IssueCommentUrl
+	case "discussion_title":
+		return "Discussion title", d.DiscussionTitleUrl
+	case "discussion_body":
+		return "Discussion body", d.DiscussionBodyUrl
+	case "discussion_comment":
+		return "Discussion comment", d.DiscussionCommentUrl
+	case "pull_request_title":
+		return "Pull request title", d.PullRequestTitleUrl
+	case "pull_request_body":
+		return "Pull request body", d.PullRequestBodyUrl
+	case "pull_request_comment":
+		return "Pull request comment", d.PullRequestCommentUrl
+	case "pull_request_review":
+		return "Pull request review", d.PullRequestReviewUrl
+	case "pull_request_review_comment":
+		return "Pull request review comment", d.PullRequestReviewCommentUrl
+	}
+	return l.Type, ""
+}
+
+// GetCodeScanningAlert fetches a single Code Scanning alert (including the rule help text)
+func (a *AlertServices) GetCodeScanningAlert(owner, repo string, number int) (*model.Alert, error) {
+	alert := &model.Alert{}
+	path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d", owner, repo, number)
+	err := client.Get(path, alert)
+	return alert, err
+}
+
+// FetchCodeScanningInstances retrieves the instances of an alert across all branches and analyses
+// Docs: GET /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}/instances
+func (a *AlertServices) FetchCodeScanningInstances(owner, repo string, number int) ([]model.Instance, error) {
+	var allInstances []model.Instance
+	path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d/instances?per_page=100", owner, repo, number)
+
+	for {
+		var pageInstances []model.Instance
+		nextUrl, err := getPages(path, &pageInstances)
+		if err != nil {
+			return nil, err
+		}
+		allInstances = append(allInstances, pageInstances...)
+		if nextUrl == "" {
+			break
+		}
+		path = nextUrl
+	}
+	return allInstances, nil
+}
+
+// ShowCodeScanningAlert renders a Code Scanning alert with its instances, a source snippet
+// of the most recent instance and the full rule help
+func (a *AlertServices) ShowCodeScanningAlert(owner, repo string, number int, jsonOutput bool) error {
+	alert, err := a.GetCodeScanningAlert(owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	alert.Instances, err = a.FetchCodeScanningInstances(owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return jsonLister(alert)
+	}
+
+	tablePrinter, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	severity := alert.Rule.Severity
+	if alert.Rule.SecuritySeverityLevel != "" {
+		severity = fmt.Sprintf("%s (security: %s)", severity, alert.Rule.SecuritySeverityLevel)
+	}
+	location := alert.MostRecentInstance.Location
+
+	tablePrinter.AddField("Alert")
+	tablePrinter.AddField(fmt.Sprintf("#%d", alert.Numer))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Rule")
+	tablePrinter.AddField(fmt.Sprintf("%s (%s)", alert.Rule.Id, alert.Rule.Description))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Tool")
+	tablePrinter.AddField(strings.TrimSpace(alert.Tool.Name + " " + alert.Tool.Version))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Severity")
+	tablePrinter.AddField(severity)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("State")
+	tablePrinter.AddField(alert.State)
+	tablePrinter.EndRow()
+	if alert.State == "dismissed" {
+		tablePrinter.AddField("Dismissed")
+		tablePrinter.AddField(fmt.Sprintf("%s by %s at %s", alert.DismissedReason, alert.DismissedBy.Login, alert.DismissedAt))
+		tablePrinter.EndRow()
+	}
+	tablePrinter.AddField("Location")
+	tablePrinter.AddField(fmt.Sprintf("%s:%d-%d", location.Path, location.StartLine, location.EndLine))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Message")
+	tablePrinter.AddField(alert.MostRecentInstance.Message.Text)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Created At")
+	tablePrinter.AddField(alert.CreatedAt)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("URL")
+	tablePrinter.AddField(alert.HtmlUrl)
+	tablePrinter.EndRow()
+	if err := tablePrinter.Render(); err != nil {
+		return err
+	}
+
+	// The instances have more columns than the summary: they get their own table
+	fmt.Printf("\nInstances (%d):\n", len(alert.Instances))
+	if len(alert.Instances) > 0 {
+		instances, err := newTablePrinter()
+		if err != nil {
+			return err
+		}
+		instances.AddHeader([]string{"Ref", "State", "Location", "Category"})
+		for _, i := range alert.Instances {
+			instances.AddField(i.Ref)
+			instances.AddField(i.State)
+			instances.AddField(fmt.Sprintf("%s:%d @ %s", i.Location.Path, i.Location.StartLine, shortSha(i.CommitSha)))
+			instances.AddField(i.Category)
+			instances.EndRow()
+		}
+		if err := instances.Render(); err != nil {
+			return err
+		}
+	}
+
+	// Source snippet at the commit of the most recent instance
+	if location.Path != "" {
+		source, err := GetRepositoryServices().GetFileContent(owner, repo, location.Path, alert.MostRecentInstance.CommitSha)
+		if err != nil {
+			fmt.Printf("\nUnable to fetch source snippet: %s\n", err)
+		} else {
+			fmt.Printf("\n%s @ %s\n", location.Path, shortSha(alert.MostRecentInstance.CommitSha))
+			fmt.Print(sourceSnippet(string(source), location.StartLine, location.EndLine, 3))
+		}
+	}
+
+	help := alert.Rule.Help
+	if help == "" {
+		help = alert.Rule.FullDescription
+	}
+	if help != "" {
+		fmt.Printf("\n%s\n", help)
+	}
+	if alert.Rule.HelpUri != "" {
+		fmt.Printf("More info: %s\n", alert.Rule.HelpUri)
+	}
+
+	return nil
+}
+
+// sourceSnippet returns the lines [start-context, end+context] with line numbers,
+// marking the lines of the alert with '>'
+func sourceSnippet(source string, start, end, context int) string {
+	lines := strings.Split(source, "\n")
+	if end < start {
+		end = start
+	}
+
+	from := max(start-context, 1)
+	to := min(end+context, len(lines))
+
+	var sb strings.Builder
+	for n := from; n <= to; n++ {
+		marker := " "
+		if n >= start && n <= end {
+			marker = ">"
+		}
+		fmt.Fprintf(&sb, "%s %5d | %s\n", marker, n, strings.TrimRight(lines[n-1], "\r"))
+	}
+	return sb.String()
+}
+
+func shortSha(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// CodeScanningDismissReasons are the reasons accepted by the API when dismissing a code scanning alert
+var CodeScanningDismissReasons = []string{"false positive", "won't fix", "used in tests"}
+
+// SecretScanningResolutions are the resolutions accepted by the API when closing a secret scanning alert
+var SecretScanningResolutions = []string{"false_positive", "wont_fix", "revoked", "used_in_tests"}
+
+// DismissCodeScanningAlert dismisses a code scanning alert with one of CodeScanningDismissReasons
+// Docs: PATCH /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}
+func (a *AlertServices) DismissCodeScanningAlert(owner, repo string, number int, reason, comment string) error {
+	if !slices.Contains(CodeScanningDismissReasons, reason) {
+		return fmt.Errorf("invalid dismiss reason '%s' (expected one of %s)", reason, strings.Join(CodeScanningDismissReasons, ", "))
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d", owner, repo, number)
+	payload := model.UpdateAlert{
+		State:            "dismissed",
+		DismissedReason:  reason,
+		DismissedComment: comment,
+	}
+	return patch(path, payload)
+}
+
+// ReopenCodeScanningAlert sets a dismissed code scanning alert back to open
+func (a *AlertServices) ReopenCodeScanningAlert(owner, repo string, number int) error {
+	path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d", owner, repo, number)
+	return patch(path, model.UpdateAlert{State: "open"})
+}
+
+// ResolveSecretScanningAlert closes a secret scanning alert with one of SecretScanningResolutions
+// Docs: PATCH /repos/{owner}/{repo}/secret-scanning/alerts/{alert_number}
+func (a *AlertServices) ResolveSecretScanningAlert(owner, repo string, number int, resolution, comment string) error {
+	if !slices.Contains(SecretScanningResolutions, resolution) {
+		return fmt.Errorf("invalid resolution '%s' (expected one of %s)", resolution, strings.Join(SecretScanningResolutions, ", "))
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/secret-scanning/alerts/%d", owner, repo, number)
+	payload := model.UpdateSecretScanningAlert{
+		State:             "resolved",
+		Resolution:        resolution,
+		ResolutionComment: comment,
+	}
+	return patch(path, payload)
+}
+
+// ReopenSecretScanningAlert sets a resolved secret scanning alert back to open
+func (a *AlertServices) ReopenSecretScanningAlert(owner, repo string, number int) error {
+	path := fmt.Sprintf("repos/%s/%s/secret-scanning/alerts/%d", owner, repo, number)
+	return patch(path, model.UpdateSecretScanningAlert{State: "open"})
+}
+
+// DismissReasons returns the reasons accepted when dismissing an alert of the given type
+func DismissReasons(alertType string) []string {
+	switch alertType {
+	case AlertTypeCodeScanning:
+		return CodeScanningDismissReasons
+	case AlertTypeSecretScanning:
+		return SecretScanningResolutions
+	case AlertTypeDependabot:
+		return DependabotDismissReasons
+	}
+	return nil
+}
+
+// DismissSecurityAlert dismisses (or resolves, for secrets) an alert of any type.
+// Returns the state of the alert afterwards.
+func (a *AlertServices) DismissSecurityAlert(alert model.SecurityAlert, reason, comment string) (string, error) {
+	owner, repo, _ := strings.Cut(alert.Repository, "/")
+	switch alert.Type {
+	case AlertTypeCodeScanning:
+		return "dismissed", a.DismissCodeScanningAlert(owner, repo, alert.Number, reason, comment)
+	case AlertTypeSecretScanning:
+		return "resolved", a.ResolveSecretScanningAlert(owner, repo, alert.Number, reason, comment)
+	case AlertTypeDependabot:
+		return "dismissed", GetDependencyServices().DismissDependabotAlert(owner, repo, alert.Number, reason, comment)
+	}
+	return "", fmt.Errorf("unknown alert type '%s'", alert.Type)
+}
+
+// ReopenSecurityAlert reopens a dismissed (or resolved) alert of any type
+func (a *AlertServices) ReopenSecurityAlert(alert model.SecurityAlert) error {
+	owner, repo, _ := strings.Cut(alert.Repository, "/")
+	switch alert.Type {
+	case AlertTypeCodeScanning:
+		return a.ReopenCodeScanningAlert(owner, repo, alert.Number)
+	case AlertTypeSecretScanning:
+		return a.ReopenSecretScanningAlert(owner, repo, alert.Number)
+	case AlertTypeDependabot:
+		return GetDependencyServices().ReopenDependabotAlert(owner, repo, alert.Number)
+	}
+	return fmt.Errorf("unknown alert type '%s'", alert.Type)
+}
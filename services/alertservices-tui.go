@@ -0,0 +1,693 @@
+package services
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cli/go-gh/v2/pkg/browser"
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used by the triage screen
+const (
+	ansiAltScreen  = "\x1b[?1049h"
+	ansiMainScreen = "\x1b[?1049l"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+	ansiClear      = "\x1b[2J"
+	ansiReset      = "\x1b[0m"
+	ansiReverse    = "\x1b[7m"
+	ansiDim        = "\x1b[2m"
+	ansiBold       = "\x1b[1m"
+)
+
+// severityColors highlights the severities in the alert list
+var severityColors = map[string]string{
+	"critical": "\x1b[1;31m",
+	"high":     "\x1b[31m",
+	"error":    "\x1b[31m",
+	"medium":   "\x1b[33m",
+	"warning":  "\x1b[33m",
+}
+
+// alertTypeLabels are the short alert type names of the list pane
+var alertTypeLabels = map[string]string{
+	AlertTypeCodeScanning:   "code",
+	AlertTypeSecretScanning: "secret",
+	AlertTypeDependabot:     "deps",
+}
+
+// Keys decoded from the terminal input, besides printable characters
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdn"
+	keyHome      = "home"
+	keyEnd       = "end"
+	keyEnter     = "enter"
+	keyEscape    = "esc"
+	keyBackspace = "backspace"
+	keyInterrupt = "ctrl-c"
+)
+
+// escapeKeys maps the escape sequences of the navigation keys (xterm and vt220 variants)
+var escapeKeys = map[string]string{
+	"\x1b[A":  keyUp,
+	"\x1bOA":  keyUp,
+	"\x1b[B":  keyDown,
+	"\x1bOB":  keyDown,
+	"\x1b[5~": keyPageUp,
+	"\x1b[6~": keyPageDown,
+	"\x1b[H":  keyHome,
+	"\x1bOH":  keyHome,
+	"\x1b[1~": keyHome,
+	"\x1b[7~": keyHome,
+	"\x1b[F":  keyEnd,
+	"\x1bOF":  keyEnd,
+	"\x1b[4~": keyEnd,
+	"\x1b[8~": keyEnd,
+}
+
+// Input modes of the triage screen
+const (
+	triageBrowse = iota
+	triageFilter
+	triageReason
+	triageComment
+)
+
+// triageScreen is the state of a triage session: the loaded alerts, the ones matching the filter
+// and what the keyboard currently edits
+type triageScreen struct {
+	svc    *AlertServices
+	target string
+	types  []string
+	state  string
+
+	alerts  []model.SecurityAlert
+	visible []int // indexes in alerts matching the filter
+	cursor  int   // position in visible
+	offset  int   // first visible row of the list pane
+
+	mode    int
+	filter  string
+	reasons []string // dismiss reasons of the selected alert type
+	reason  string
+	comment string
+	detail  bool // narrow terminals show the detail instead of the list
+	status  string
+
+	width, height int
+	out           *bufio.Writer
+}
+
+// Triage opens a full-screen view of the alerts of a repository or organization: a list pane, a detail
+// pane and a filter bar, with keyboard actions to dismiss, reopen and open alerts in the browser.
+// state filters the listed alerts ("open" by default, empty for every state).
+func (a *AlertServices) Triage(target string, types []string, state string) error {
+	for _, t := range types {
+		if !slices.Contains(AlertTypes, t) {
+			return fmt.Errorf("unknown alert type '%s' (expected %s)", t, strings.Join(AlertTypes, ", "))
+		}
+	}
+
+	// GetTerminal also enables the escape sequences on Windows consoles
+	if !GetTerminal().IsTerminalOutput() || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New("triage needs an interactive terminal: use 'list alerts' to script the alert listing")
+	}
+
+	fmt.Fprintf(os.Stderr, "Loading the alerts of %s...\n", target)
+	alerts, err := a.FetchSecurityAlerts(target, types, state)
+	if err != nil {
+		return err
+	}
+
+	s := &triageScreen{
+		svc:    a,
+		target: target,
+		types:  types,
+		state:  state,
+		alerts: alerts,
+		out:    bufio.NewWriter(os.Stdout),
+	}
+	s.applyFilter()
+	return s.run()
+}
+
+// run switches the terminal to raw mode and the alternate screen, and handles keys until the user quits
+func (s *triageScreen) run() error {
+	fd := int(os.Stdin.Fd())
+	previous, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, previous)
+
+	fmt.Fprint(os.Stdout, ansiAltScreen+ansiHideCursor)
+	defer fmt.Fprint(os.Stdout, ansiShowCursor+ansiMainScreen)
+
+	keys := make(chan string)
+	go readKeys(os.Stdin, keys)
+
+	// Poll the terminal size rather than relying on SIGWINCH, which Windows doesn't have
+	resize := time.NewTicker(250 * time.Millisecond)
+	defer resize.Stop()
+
+	s.resize()
+	s.draw()
+	for {
+		select {
+		case key, ok := <-keys:
+			if !ok || !s.handleKey(key) {
+				return nil
+			}
+		case <-resize.C:
+			if !s.resize() {
+				continue
+			}
+		}
+		s.draw()
+	}
+}
+
+// resize reads the terminal size, and reports whether it changed
+func (s *triageScreen) resize() bool {
+	w, h, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || (w == s.width && h == s.height) {
+		return false
+	}
+	s.width, s.height = w, h
+	s.scroll()
+	return true
+}
+
+// readKeys decodes the raw terminal input into keys until stdin is closed
+func readKeys(in io.Reader, keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 64)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range decodeKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// decodeKeys splits a read of the terminal input into keys. An escape sequence arrives in a single read,
+// so a lone ESC is the Escape key.
+func decodeKeys(input []byte) []string {
+	keys := []string{}
+	for len(input) > 0 {
+		switch c := input[0]; {
+		case c == 0x1b:
+			size := 1
+			if len(input) > 2 && (input[1] == '[' || input[1] == 'O') {
+				// CSI/SS3 sequence: parameters, then a final byte in @..~
+				size = 2
+				for size < len(input) && (input[size] < 0x40 || input[size] > 0x7e) {
+					size++
+				}
+				size = min(size+1, len(input))
+			}
+			if size == 1 {
+				keys = append(keys, keyEscape)
+			} else if key, ok := escapeKeys[string(input[:size])]; ok {
+				keys = append(keys, key)
+			}
+			input = input[size:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, keyEnter)
+			input = input[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, keyBackspace)
+			input = input[1:]
+		case c == 0x03:
+			keys = append(keys, keyInterrupt)
+			input = input[1:]
+		case c < 0x20:
+			input = input[1:]
+		default:
+			r, size := utf8.DecodeRune(input)
+			keys = append(keys, string(r))
+			input = input[size:]
+		}
+	}
+	return keys
+}
+
+// handleKey applies a key to the screen. Returns false when the user quits.
+func (s *triageScreen) handleKey(key string) bool {
+	if key == keyInterrupt {
+		return false
+	}
+	s.status = ""
+
+	switch s.mode {
+	case triageFilter:
+		switch key {
+		case keyEnter:
+			s.mode = triageBrowse
+		case keyEscape:
+			s.mode = triageBrowse
+			s.filter = ""
+		case keyBackspace:
+			s.filter = dropLastRune(s.filter)
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				s.filter += key
+			}
+		}
+		s.applyFilter()
+		return true
+
+	case triageReason:
+		switch {
+		case key == keyEscape:
+			s.mode = triageBrowse
+		case len(key) == 1 && key[0] >= '1' && int(key[0]-'0') <= len(s.reasons):
+			s.reason = s.reasons[key[0]-'1']
+			s.comment = ""
+			s.mode = triageComment
+		}
+		return true
+
+	case triageComment:
+		switch key {
+		case keyEnter:
+			s.mode = triageBrowse
+			s.dismiss()
+		case keyEscape:
+			s.mode = triageBrowse
+		case keyBackspace:
+			s.comment = dropLastRune(s.comment)
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				s.comment += key
+			}
+		}
+		return true
+	}
+
+	switch key {
+	case "q":
+		return false
+	case keyUp, "k":
+		s.move(-1)
+	case keyDown, "j":
+		s.move(1)
+	case keyPageUp:
+		s.move(-s.listHeight())
+	case keyPageDown, " ":
+		s.move(s.listHeight())
+	case keyHome, "g":
+		s.move(-len(s.visible))
+	case keyEnd, "G":
+		s.move(len(s.visible))
+	case keyEnter:
+		s.detail = !s.detail
+	case keyEscape:
+		s.detail = false
+	case "/":
+		s.mode = triageFilter
+	case "d":
+		if alert := s.selected(); alert != nil {
+			if alert.State != "open" {
+				s.status = fmt.Sprintf("#%d is already %s", alert.Number, alert.State)
+			} else {
+				s.reasons = DismissReasons(alert.Type)
+				s.mode = triageReason
+			}
+		}
+	case "r":
+		s.reopen()
+	case "o":
+		if alert := s.selected(); alert != nil {
+			if err := browser.New("", io.Discard, io.Discard).Browse(alert.URL); err != nil {
+				s.status = fmt.Sprintf("Unable to open the browser: %v", err)
+			} else {
+				s.status = "Opened " + alert.URL
+			}
+		}
+	case "R":
+		s.refresh()
+	}
+	return true
+}
+
+// selected returns the alert under the cursor (nil when the list is empty)
+func (s *triageScreen) selected() *model.SecurityAlert {
+	if len(s.visible) == 0 {
+		return nil
+	}
+	return &s.alerts[s.visible[s.cursor]]
+}
+
+func (s *triageScreen) move(delta int) {
+	s.cursor = max(0, min(s.cursor+delta, len(s.visible)-1))
+	s.scroll()
+}
+
+// scroll keeps the cursor inside the list pane
+func (s *triageScreen) scroll() {
+	height := s.listHeight()
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+height {
+		s.offset = s.cursor - height + 1
+	}
+	s.offset = max(0, min(s.offset, len(s.visible)-height))
+}
+
+// applyFilter selects the alerts matching every word of the filter (case insensitive) in their
+// repository, type, state, severity, rule, title or path, keeping the cursor on the same alert
+func (s *triageScreen) applyFilter() {
+	current := -1
+	if len(s.visible) > 0 {
+		current = s.visible[s.cursor]
+	}
+
+	words := strings.Fields(strings.ToLower(s.filter))
+	s.visible = s.visible[:0]
+	s.cursor = 0
+	for i, alert := range s.alerts {
+		text := strings.ToLower(strings.Join([]string{alert.Repository, alert.Type, alert.State, alert.Severity, alert.Rule, alert.Title, alert.Path}, " "))
+		matches := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			if i == current {
+				s.cursor = len(s.visible)
+			}
+			s.visible = append(s.visible, i)
+		}
+	}
+	s.scroll()
+}
+
+// dismiss dismisses (or resolves, for secrets) the selected alert with the chosen reason and comment
+func (s *triageScreen) dismiss() {
+	alert := s.selected()
+	if alert == nil {
+		return
+	}
+	s.status = fmt.Sprintf("Dismissing #%d...", alert.Number)
+	s.draw()
+
+	state, err := s.svc.DismissSecurityAlert(*alert, s.reason, s.comment)
+	if err != nil {
+		s.status = fmt.Sprintf("Unable to dismiss #%d: %v", alert.Number, err)
+		return
+	}
+	// The alert stays listed until the next refresh, so the dismissal can be undone with 'r'
+	alert.State = state
+	s.status = fmt.Sprintf("%s %s #%d (%s)", capitalize(state), alert.Repository, alert.Number, s.reason)
+}
+
+// reopen reopens the selected alert when it was dismissed or resolved
+func (s *triageScreen) reopen() {
+	alert := s.selected()
+	if alert == nil {
+		return
+	}
+	if alert.State == "open" {
+		s.status = fmt.Sprintf("#%d is already open", alert.Number)
+		return
+	}
+	if alert.State == "fixed" {
+		s.status = fmt.Sprintf("#%d is fixed: only dismissed alerts can be reopened", alert.Number)
+		return
+	}
+	s.status = fmt.Sprintf("Reopening #%d...", alert.Number)
+	s.draw()
+
+	if err := s.svc.ReopenSecurityAlert(*alert); err != nil {
+		s.status = fmt.Sprintf("Unable to reopen #%d: %v", alert.Number, err)
+		return
+	}
+	alert.State = "open"
+	s.status = fmt.Sprintf("Reopened %s #%d", alert.Repository, alert.Number)
+}
+
+// refresh reloads the alerts, keeping the filter and the cursor on the same alert when it is still listed
+func (s *triageScreen) refresh() {
+	s.status = "Refreshing..."
+	s.draw()
+
+	alerts, err := s.svc.FetchSecurityAlerts(s.target, s.types, s.state)
+	if err != nil {
+		s.status = fmt.Sprintf("Unable to refresh: %v", err)
+		return
+	}
+
+	var current model.SecurityAlert
+	if alert := s.selected(); alert != nil {
+		current = *alert
+	}
+	s.alerts = alerts
+	s.visible = nil
+	s.applyFilter()
+	for i, index := range s.visible {
+		a := s.alerts[index]
+		if a.Repository == current.Repository && a.Type == current.Type && a.Number == current.Number {
+			s.cursor = i
+			break
+		}
+	}
+	s.scroll()
+	s.status = fmt.Sprintf("%d alerts loaded", len(alerts))
+}
+
+// listHeight is the number of rows between the title and filter bars and the status line
+func (s *triageScreen) listHeight() int {
+	return max(1, s.height-3)
+}
+
+// draw renders the whole screen in a single write to avoid flickering
+func (s *triageScreen) draw() {
+	w, h := s.width, s.height
+	if w < 20 || h < 5 {
+		fmt.Fprint(s.out, ansiClear+"\x1b[HTerminal too small")
+		s.out.Flush()
+		return
+	}
+	s.out.WriteString("\x1b[H")
+
+	state := s.state
+	if state == "" {
+		state = "all"
+	}
+	title := fmt.Sprintf(" Triage %s · %d of %d %s alerts", s.target, len(s.visible), len(s.alerts), state)
+	if len(s.visible) > 0 {
+		title += fmt.Sprintf(" · %d/%d", s.cursor+1, len(s.visible))
+	}
+	s.line(ansiReverse+ansiBold, title, w)
+
+	filter := " Filter: " + s.filter
+	if s.mode == triageFilter {
+		filter += "█"
+	}
+	if s.mode != triageFilter && s.filter == "" {
+		hint := "(press / to filter by repository, type, severity, rule, title or path)"
+		s.out.WriteString(filter)
+		s.line(ansiDim, hint, w-utf8.RuneCountInString(filter))
+	} else {
+		s.line("", filter, w)
+	}
+
+	// Panes side by side on wide terminals, one at a time (Enter to switch) on narrow ones
+	listWidth, detailWidth := w, 0
+	if w >= 100 {
+		listWidth = w * 55 / 100
+		detailWidth = w - listWidth - 1
+	} else if s.detail {
+		listWidth, detailWidth = 0, w
+	}
+
+	detail := s.detailLines(max(detailWidth-2, 1))
+	for row := 0; row < s.listHeight(); row++ {
+		if listWidth > 0 {
+			s.listRow(s.offset+row, listWidth)
+		}
+		if listWidth > 0 && detailWidth > 0 {
+			s.out.WriteString(ansiDim + "│" + ansiReset)
+		}
+		if detailWidth > 0 {
+			text := ""
+			if row < len(detail) {
+				text = detail[row]
+			}
+			s.out.WriteString(" " + pad(text, detailWidth-1))
+		}
+		s.out.WriteString("\x1b[K\r\n")
+	}
+
+	// No line feed after the status line: it would scroll the screen
+	s.out.WriteString(ansiReverse + pad(s.statusLine(), w) + ansiReset)
+	s.out.Flush()
+}
+
+// line writes a full-width line with an optional style
+func (s *triageScreen) line(style, text string, width int) {
+	s.out.WriteString(style + pad(text, width) + ansiReset + "\x1b[K\r\n")
+}
+
+// listRow writes a row of the list pane: severity, type, repository#number and title
+func (s *triageScreen) listRow(index, width int) {
+	if index >= len(s.visible) {
+		s.out.WriteString(strings.Repeat(" ", width))
+		return
+	}
+	alert := s.alerts[s.visible[index]]
+
+	location := fmt.Sprintf("#%d", alert.Number)
+	if !strings.Contains(s.target, "/") {
+		location = strings.TrimPrefix(alert.Repository, s.target+"/") + location
+	}
+	text := fmt.Sprintf(" %-8s %-6s %s %s", alert.Severity, alertTypeLabels[alert.Type], location, alert.Title)
+	if alert.State != "open" {
+		text = fmt.Sprintf(" %-8s %-6s %s [%s] %s", alert.Severity, alertTypeLabels[alert.Type], location, alert.State, alert.Title)
+	}
+
+	style := severityColors[alert.Severity]
+	switch {
+	case index == s.cursor:
+		style = ansiReverse
+	case alert.State != "open":
+		style = ansiDim
+	}
+	s.out.WriteString(style + pad(text, width) + ansiReset)
+}
+
+// detailLines renders the selected alert for the detail pane, wrapped to its width
+func (s *triageScreen) detailLines(width int) []string {
+	alert := s.selected()
+	if alert == nil {
+		return []string{"No alert matches the filter"}
+	}
+
+	lines := wrapText(alert.Title, width)
+	lines = append(lines, "")
+	field := func(name, value string) {
+		if value == "" || value == "0" {
+			return
+		}
+		// Values wrap under themselves, aligned after the labels
+		for i, l := range wrapText(value, max(width-12, 1)) {
+			label := ""
+			if i == 0 {
+				label = name + ":"
+			}
+			lines = append(lines, fmt.Sprintf("%-11s %s", label, l))
+		}
+	}
+	field("Repository", alert.Repository)
+	field("Type", alert.Type)
+	field("Number", fmt.Sprintf("%d", alert.Number))
+	field("State", alert.State)
+	field("Severity", alert.Severity)
+	field("Rule", alert.Rule)
+	location := alert.Path
+	if alert.Line > 0 {
+		location += fmt.Sprintf(":%d", alert.Line)
+	}
+	field("Location", location)
+	field("Validity", alert.Validity)
+	if alert.EPSS > 0 {
+		field("EPSS", fmt.Sprintf("%.0f%% percentile", alert.EPSS*100))
+	}
+	field("Created", alert.CreatedAt)
+	field("URL", alert.URL)
+	return lines
+}
+
+// statusLine shows the prompt of the current mode, the result of the last action or the key help
+func (s *triageScreen) statusLine() string {
+	switch s.mode {
+	case triageFilter:
+		return " Type to filter · Enter keep · Esc clear"
+	case triageReason:
+		choices := []string{}
+		for i, r := range s.reasons {
+			choices = append(choices, fmt.Sprintf("%d) %s", i+1, r))
+		}
+		return " Reason: " + strings.Join(choices, "  ") + " · Esc cancel"
+	case triageComment:
+		return fmt.Sprintf(" Comment (optional): %s█ · Enter dismiss as '%s' · Esc cancel", s.comment, s.reason)
+	}
+	if s.status != "" {
+		return " " + s.status
+	}
+	return " ↑↓/jk move · PgUp/PgDn · / filter · d dismiss · r reopen · o open · R refresh · Enter detail · q quit"
+}
+
+// pad truncates or pads text to width runes (text must not contain escape sequences)
+func pad(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	n := utf8.RuneCountInString(text)
+	if n > width {
+		runes := []rune(text)
+		return string(runes[:width-1]) + "…"
+	}
+	return text + strings.Repeat(" ", width-n)
+}
+
+// wrapText splits text into lines of at most width runes, on spaces when possible
+func wrapText(text string, width int) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		for utf8.RuneCountInString(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func dropLastRune(text string) string {
+	_, size := utf8.DecodeLastRuneInString(text)
+	return text[:len(text)-size]
+}
+
+func capitalize(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
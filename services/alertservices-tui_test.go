@@ -0,0 +1,63 @@
+package services
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDecodeKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"letters", "jk", []string{"j", "k"}},
+		{"multi-byte rune", "é/", []string{"é", "/"}},
+		{"arrows", "\x1b[A\x1b[B", []string{keyUp, keyDown}},
+		{"application mode arrows", "\x1bOA\x1bOB", []string{keyUp, keyDown}},
+		{"page keys", "\x1b[5~\x1b[6~", []string{keyPageUp, keyPageDown}},
+		{"home and end", "\x1b[H\x1b[4~", []string{keyHome, keyEnd}},
+		{"lone escape", "\x1b", []string{keyEscape}},
+		{"unknown sequence is dropped", "\x1b[2~q", []string{"q"}},
+		{"function key with modifiers is dropped", "\x1b[1;5Cq", []string{"q"}},
+		{"enter", "\r\n", []string{keyEnter, keyEnter}},
+		{"backspace", "a\x7f\x08", []string{"a", keyBackspace, keyBackspace}},
+		{"interrupt", "\x03", []string{keyInterrupt}},
+		{"other control characters", "\x01\x02x", []string{"x"}},
+		{"incomplete sequence", "\x1b[", []string{keyEscape, "["}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeKeys([]byte(tt.input)); !slices.Equal(got, tt.want) {
+				t.Errorf("decodeKeys(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"empty", "", 10, []string{""}},
+		{"fits", "short text", 10, []string{"short text"}},
+		{"on spaces", "the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"collapses spaces", "a   b\n\tc", 10, []string{"a b c"}},
+		{"long word", "abcdefghijkl", 5, []string{"abcde", "fghij", "kl"}},
+		{"long word after text", "ab abcdefghij cd", 5, []string{"ab", "abcde", "fghij", "cd"}},
+		{"counts runes", "çãé ôü", 6, []string{"çãé ôü"}},
+		{"splits runes", "çãéôü", 2, []string{"çã", "éô", "ü"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.width); !slices.Equal(got, tt.want) {
+				t.Errorf("wrapText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}
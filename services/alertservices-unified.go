@@ -0,0 +1,190 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// Alert types handled by the commands working across alert types
+const (
+	AlertTypeCodeScanning   = "code-scanning"
+	AlertTypeSecretScanning = "secret-scanning"
+	AlertTypeDependabot     = "dependabot"
+)
+
+var AlertTypes = []string{AlertTypeCodeScanning, AlertTypeSecretScanning, AlertTypeDependabot}
+
+// AlertSeverities are the normalized severities of a SecurityAlert, from lowest to highest
+var AlertSeverities = []string{"low", "medium", "high", "critical"}
+
+// AlertSeverityRank returns the position of a severity in AlertSeverities (-1 when unknown)
+func AlertSeverityRank(severity string) int {
+	for i, s := range AlertSeverities {
+		if strings.EqualFold(s, severity) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FetchSecurityAlerts retrieves the alerts of the given types for a repository ("owner/repo") or
+// for a whole organization, using the organization level endpoints. An empty state returns every alert.
+func (a *AlertServices) FetchSecurityAlerts(target string, types []string, state string) ([]model.SecurityAlert, error) {
+	alerts := []model.SecurityAlert{}
+	for _, t := range types {
+		path, err := securityAlertsPath(target, t, state)
+		if err != nil {
+			return nil, err
+		}
+		items, err := fetchAllPages[json.RawMessage](path)
+		if err != nil {
+			return nil, err
+		}
+		found, err := decodeSecurityAlerts(target, t, items)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, found...)
+	}
+	return alerts, nil
+}
+
+// securityAlertsPath returns the listing endpoint of an alert type, at organization or repository level
+func securityAlertsPath(target, alertType, state string) (string, error) {
+	if !slices.Contains(AlertTypes, alertType) {
+		return "", fmt.Errorf("unknown alert type '%s' (expected %s)", alertType, strings.Join(AlertTypes, ", "))
+	}
+
+	scope := "orgs/" + target
+	if strings.Contains(target, "/") {
+		scope = "repos/" + target
+	}
+	query := "?per_page=100"
+	if state != "" {
+		query += "&state=" + state
+	}
+	return fmt.Sprintf("%s/%s/alerts%s", scope, alertType, query), nil
+}
+
+// decodeSecurityAlerts converts the items of an alert listing to SecurityAlerts
+func decodeSecurityAlerts(target, alertType string, items []json.RawMessage) ([]model.SecurityAlert, error) {
+	alerts := make([]model.SecurityAlert, 0, len(items))
+	for _, item := range items {
+		var alert model.SecurityAlert
+		switch alertType {
+		case AlertTypeCodeScanning:
+			var found model.Alert
+			if err := json.Unmarshal(item, &found); err != nil {
+				return nil, err
+			}
+			alert = fromCodeScanningAlert(target, found)
+		case AlertTypeSecretScanning:
+			var found model.SecretScanningAlert
+			if err := json.Unmarshal(item, &found); err != nil {
+				return nil, err
+			}
+			alert = fromSecretScanningAlert(target, found)
+		case AlertTypeDependabot:
+			var found model.DependabotAlert
+			if err := json.Unmarshal(item, &found); err != nil {
+				return nil, err
+			}
+			alert = fromDependabotAlert(target, found)
+		default:
+			return nil, fmt.Errorf("unknown alert type '%s' (expected %s)", alertType, strings.Join(AlertTypes, ", "))
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, nil
+}
+
+// alertRepository returns the repository of an alert: organization level listings carry it,
+// repository level listings don't
+func alertRepository(target string, repository model.Repository) string {
+	if repository.FullName != "" {
+		return repository.FullName
+	}
+	return target
+}
+
+func fromCodeScanningAlert(target string, alert model.Alert) model.SecurityAlert {
+	// security_severity_level is only set for security queries; map the rule severity otherwise
+	severity := alert.Rule.SecuritySeverityLevel
+	if severity == "" {
+		severity = map[string]string{"error": "high", "warning": "medium", "note": "low"}[alert.Rule.Severity]
+	}
+	if severity == "" {
+		severity = "low"
+	}
+
+	return model.SecurityAlert{
+		Repository: alertRepository(target, alert.Repository),
+		Type:       AlertTypeCodeScanning,
+		Number:     alert.Numer,
+		State:      alert.State,
+		Severity:   severity,
+		Rule:       alert.Rule.Id,
+		Title:      alert.Rule.Description,
+		Path:       alert.MostRecentInstance.Location.Path,
+		Line:       alert.MostRecentInstance.Location.StartLine,
+		URL:        alert.HtmlUrl,
+		CreatedAt:  alert.CreatedAt,
+	}
+}
+
+func fromSecretScanningAlert(target string, alert model.SecretScanningAlert) model.SecurityAlert {
+	// Secrets have no severity: a leaked credential is high, critical while it is still valid
+	severity := "high"
+	if alert.Validity == "active" {
+		severity = "critical"
+	}
+
+	title := alert.SecretTypeDisplayName
+	if title == "" {
+		title = alert.SecretType
+	}
+
+	securityAlert := model.SecurityAlert{
+		Repository: alertRepository(target, alert.Repository),
+		Type:       AlertTypeSecretScanning,
+		Number:     alert.Number,
+		State:      alert.State,
+		Severity:   severity,
+		Rule:       alert.SecretType,
+		Title:      title,
+		URL:        alert.HtmlUrl,
+		CreatedAt:  alert.CreatedAt,
+		Validity:   alert.Validity,
+	}
+	if alert.FirstLocationDetected != nil {
+		securityAlert.Path = alert.FirstLocationDetected.Path
+		securityAlert.Line = alert.FirstLocationDetected.StartLine
+	}
+	return securityAlert
+}
+
+func fromDependabotAlert(target string, alert model.DependabotAlert) model.SecurityAlert {
+	severity := strings.ToLower(alert.SecurityAdvisory.Severity)
+	if severity == "moderate" {
+		severity = "medium"
+	}
+
+	epss, _ := advisoryEPSS(alert.SecurityAdvisory)
+	return model.SecurityAlert{
+		Repository: alertRepository(target, alert.Repository),
+		Type:       AlertTypeDependabot,
+		Number:     alert.Number,
+		State:      alert.State,
+		Severity:   severity,
+		Rule:       alert.SecurityAdvisory.GHSAId,
+		Title:      fmt.Sprintf("%s: %s", alert.Dependency.Package.Name, alert.SecurityAdvisory.Summary),
+		Path:       alert.Dependency.ManifestPath,
+		URL:        alert.HtmlUrl,
+		CreatedAt:  alert.CreatedAt,
+		EPSS:       epss,
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// MinWatchInterval keeps the polling within a reasonable share of the rate limit
+const MinWatchInterval = 30 * time.Second
+
+// WatchOptions configures a watch session
+type WatchOptions struct {
+	Types     []string
+	Interval  time.Duration
+	Notifiers []string // names from the config file, no notification when empty
+	StateFile string   // optional: resume from (and keep updating) a snapshot shared with alerts notify
+}
+
+// cachedPage is the last response of a listing page, replayed when the API answers 304 Not Modified
+type cachedPage struct {
+	etag  string
+	items []json.RawMessage
+	next  string
+}
+
+// alertPoller lists alerts with conditional requests, so unchanged pages cost no rate limit
+type alertPoller struct {
+	pages       map[string]*cachedPage
+	requests    int
+	notModified int
+}
+
+// fetch lists the open alerts of the given types, reusing the cached pages that did not change
+func (p *alertPoller) fetch(target string, types []string) ([]model.SecurityAlert, error) {
+	alerts := []model.SecurityAlert{}
+	for _, t := range types {
+		path, err := securityAlertsPath(target, t, "open")
+		if err != nil {
+			return nil, err
+		}
+
+		items := []json.RawMessage{}
+		for path != "" {
+			page, err := p.page(path)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, page.items...)
+			path = page.next
+		}
+
+		found, err := decodeSecurityAlerts(target, t, items)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, found...)
+	}
+	return alerts, nil
+}
+
+func (p *alertPoller) page(path string) (*cachedPage, error) {
+	cached := p.pages[path]
+	etag := ""
+	if cached != nil {
+		etag = cached.etag
+	}
+
+	p.requests++
+	body, newETag, next, notModified, err := conditionalGet(path, etag)
+	if err != nil {
+		return nil, err
+	}
+	if notModified {
+		p.notModified++
+		return cached, nil
+	}
+
+	page := &cachedPage{etag: newETag, next: next}
+	if err := json.Unmarshal(body, &page.items); err != nil {
+		return nil, err
+	}
+	p.pages[path] = page
+	return page, nil
+}
+
+// WatchAlerts polls the open alerts of a repository or organization until ctx is cancelled and reports
+// the new, reopened and resolved (fixed, dismissed or closed) alerts as they are detected: printed,
+// one JSON event per line with jsonOutput, and sent to the selected notifiers.
+// Polling errors are reported and retried on the next tick, as a watch is meant to run unattended.
+func (a *AlertServices) WatchAlerts(ctx context.Context, target string, opts WatchOptions, jsonOutput bool) error {
+	if opts.Interval < MinWatchInterval {
+		return fmt.Errorf("the interval must be at least %s", MinWatchInterval)
+	}
+	for _, t := range opts.Types {
+		if !slices.Contains(AlertTypes, t) {
+			return fmt.Errorf("unknown alert type '%s' (expected %s)", t, strings.Join(AlertTypes, ", "))
+		}
+	}
+
+	var notifiers []Notifier
+	if len(opts.Notifiers) > 0 {
+		var err error
+		if notifiers, err = LoadNotifiers(opts.Notifiers); err != nil {
+			return err
+		}
+	}
+
+	var snapshot *model.AlertSnapshot
+	if opts.StateFile != "" {
+		var err error
+		if snapshot, err = LoadAlertSnapshot(opts.StateFile); err != nil {
+			return err
+		}
+	}
+
+	poller := &alertPoller{pages: map[string]*cachedPage{}}
+	encoder := json.NewEncoder(os.Stdout)
+
+	// Status lines go to stderr so the event stream stays parseable
+	fmt.Fprintf(os.Stderr, "Watching %s (%s) every %s. Press Ctrl+C to stop.\n", target, strings.Join(opts.Types, ", "), opts.Interval)
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		poller.requests, poller.notModified = 0, 0
+		open, err := poller.fetch(target, opts.Types)
+		now := time.Now()
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s poll failed: %v\n", now.Format(time.TimeOnly), err)
+		} else {
+			events, next := DiffAlerts(target, snapshot, open)
+			if snapshot == nil {
+				// First poll: the open alerts are the baseline, not news
+				events = nil
+				fmt.Fprintf(os.Stderr, "%s %d open alerts\n", now.Format(time.TimeOnly), len(open))
+			} else {
+				fmt.Fprintf(os.Stderr, "%s %d open alerts, %d changes (%d of %d requests not modified)\n",
+					now.Format(time.TimeOnly), len(open), len(events), poller.notModified, poller.requests)
+			}
+
+			for i := range events {
+				events[i].DetectedAt = now.UTC().Format(time.RFC3339)
+				e := events[i]
+				if jsonOutput {
+					encoder.Encode(e)
+				} else {
+					fmt.Printf("%s %-8s %s %s #%d [%s] %s\n", now.Format(time.DateTime), e.Event, e.Alert.Repository, e.Alert.Type, e.Alert.Number, e.Alert.Severity, e.Alert.Title)
+				}
+			}
+
+			// A failing notifier keeps its events, to receive them on the next poll
+			if snapshot != nil {
+				next.Pending = snapshot.Pending
+			}
+			if len(notifiers) > 0 && (len(events) > 0 || len(next.Pending) > 0) {
+				if next.Pending, err = NotifyPending(notifiers, next.Pending, events); err != nil {
+					fmt.Fprintf(os.Stderr, "%s notification failed: %v\n", now.Format(time.TimeOnly), err)
+				}
+			}
+
+			snapshot = next
+			if opts.StateFile != "" {
+				if err := SaveAlertSnapshot(opts.StateFile, snapshot); err != nil {
+					fmt.Fprintf(os.Stderr, "%s %v\n", now.Format(time.TimeOnly), err)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
     "fmt"
+    "net/url"
 
     "github.com/messagedigest-net/gh-advanced-security/model"
 )
@@ -21,11 +22,16 @@ func GetAlertServices() *AlertServices {
     return alertSvcs
 }
 
-// ListCodeScanning fetches and displays Code Scanning alerts
-func (a *AlertServices) ListCodeScanning(org, repo string, jsonOutput bool, userPageSize int, fetchAll bool) error {
+// ListCodeScanning fetches and displays Code Scanning alerts, with their owners when requested
+func (a *AlertServices) ListCodeScanning(org, repo string, ownership OwnershipOptions, jsonOutput bool, userPageSize int, fetchAll bool) error {
     pageSize := GetOptimalPageSize(userPageSize)
     path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts?per_page=%d", org, repo, pageSize)
 
+    resolver, err := newOwnershipResolver(ownership)
+    if err != nil {
+        return err
+    }
+
     a.codeAlerts = []model.Alert{}
 
     for {
@@ -35,6 +41,10 @@ func (a *AlertServices) ListCodeScanning(org, repo string, jsonOutput bool, user
             return err
         }
 
+        pageAlerts = assignOwners(resolver, ownership, pageAlerts, func(alert *model.Alert) (string, string, *[]string) {
+            return org + "/" + repo, alert.MostRecentInstance.Location.Path, &alert.Owners
+        })
+
         if jsonOutput {
             a.codeAlerts = append(a.codeAlerts, pageAlerts...)
             if nextUrl == "" {
@@ -46,7 +56,7 @@ func (a *AlertServices) ListCodeScanning(org, repo string, jsonOutput bool, user
 
         // Interactive Render
         a.codeAlerts = pageAlerts
-        if err := a.printCodeScanningTable(); err != nil {
+        if err := a.printCodeScanningTable(ownership.Show); err != nil {
             return err
         }
 
@@ -69,10 +79,19 @@ func (a *AlertServices) ListCodeScanning(org, repo string, jsonOutput bool, user
     return nil
 }
 
-// ListSecretScanning fetches and displays Secret Scanning alerts
-func (a *AlertServices) ListSecretScanning(org, repo string, jsonOutput bool, userPageSize int, fetchAll bool) error {
+// ListSecretScanning fetches and displays Secret Scanning alerts, with their owners when requested
+// validity optionally filters by token validity (active, inactive, unknown - comma separated)
+func (a *AlertServices) ListSecretScanning(org, repo, validity string, ownership OwnershipOptions, jsonOutput bool, userPageSize int, fetchAll bool) error {
     pageSize := GetOptimalPageSize(userPageSize)
     path := fmt.Sprintf("repos/%s/%s/secret-scanning/alerts?per_page=%d", org, repo, pageSize)
+    if validity != "" {
+        path += "&validity=" + url.QueryEscape(validity)
+    }
+
+    resolver, err := newOwnershipResolver(ownership)
+    if err != nil {
+        return err
+    }
 
     a.secretAlerts = []model.SecretScanningAlert{}
 
@@ -83,6 +102,10 @@ func (a *AlertServices) ListSecretScanning(org, repo string, jsonOutput bool, us
             return err
         }
 
+        pageAlerts = assignOwners(resolver, ownership, pageAlerts, func(alert *model.SecretScanningAlert) (string, string, *[]string) {
+            return org + "/" + repo, secretScanningPath(*alert), &alert.Owners
+        })
+
         if jsonOutput {
             a.secretAlerts = append(a.secretAlerts, pageAlerts...)
             if nextUrl == "" {
@@ -93,7 +116,7 @@ func (a *AlertServices) ListSecretScanning(org, repo string, jsonOutput bool, us
         }
 
         a.secretAlerts = pageAlerts
-        if err := a.printSecretScanningTable(); err != nil {
+        if err := a.printSecretScanningTable(ownership.Show); err != nil {
             return err
         }
 
@@ -116,13 +139,17 @@ func (a *AlertServices) ListSecretScanning(org, repo string, jsonOutput bool, us
 }
 
 // Helper to print Code Scanning table
-func (a *AlertServices) printCodeScanningTable() error {
+func (a *AlertServices) printCodeScanningTable(showOwners bool) error {
     tp, err := getTablePrinter()
     if err != nil {
         return err
     }
 
-    tp.AddHeader([]string{"ID", "State", "Tool", "Rule ID", "Description", "Created At"})
+    headers := []string{"ID", "State", "Tool", "Rule ID", "Description", "Created At"}
+    if showOwners {
+        headers = append(headers, "Owner")
+    }
+    tp.AddHeader(headers)
 
     for _, alert := range a.codeAlerts {
         tp.AddField(fmt.Sprintf("%d", alert.Numer)) // Note: 'Numer' matches your existing model
@@ -137,6 +164,9 @@ func (a *AlertServices) printCodeScanningTable() error {
         }
         tp.AddField(desc)
         tp.AddField(alert.CreatedAt)
+        if showOwners {
+            tp.AddField(formatOwners(alert.Owners))
+        }
         tp.EndRow()
     }
 
@@ -144,19 +174,29 @@ func (a *AlertServices) printCodeScanningTable() error {
 }
 
 // Helper to print Secret Scanning table
-func (a *AlertServices) printSecretScanningTable() error {
+func (a *AlertServices) printSecretScanningTable(showOwners bool) error {
     tp, err := getTablePrinter()
     if err != nil {
         return err
     }
 
-    tp.AddHeader([]string{"ID", "State", "Secret Type", "Resolution", "Push Protection", "Created At"})
+    headers := []string{"ID", "State", "Secret Type", "Validity", "Resolution", "Push Protection", "Created At"}
+    if showOwners {
+        headers = append(headers, "Owner")
+    }
+    tp.AddHeader(headers)
 
     for _, alert := range a.secretAlerts {
         tp.AddField(fmt.Sprintf("%d", alert.Number))
         tp.AddField(alert.State)
         tp.AddField(alert.SecretTypeDisplayName)
 
+        validity := alert.Validity
+        if validity == "" {
+            validity = "unknown"
+        }
+        tp.AddField(validity)
+
         resolution := alert.Resolution
         if resolution == "" {
             resolution = "-"
@@ -165,6 +205,9 @@ func (a *AlertServices) printSecretScanningTable() error {
 
         tp.AddField(enabledOrDisabled(alert.PushProtectionBypassed))
         tp.AddField(alert.CreatedAt)
+        if showOwners {
+            tp.AddField(formatOwners(alert.Owners))
+        }
         tp.EndRow()
     }
 
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CodeownersFiles are the locations GitHub reads CODEOWNERS from, in order
+var CodeownersFiles = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeownersRule is a CODEOWNERS line: a gitignore-style pattern and its owners (@user, @org/team or e-mail)
+type CodeownersRule struct {
+	Pattern string
+	Owners  []string
+	regex   *regexp.Regexp
+}
+
+// Codeowners is a parsed CODEOWNERS file
+type Codeowners []CodeownersRule
+
+// ParseCodeowners parses the content of a CODEOWNERS file, skipping comments and invalid patterns
+func ParseCodeowners(content string) Codeowners {
+	var rules Codeowners
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(stripCodeownersComment(line))
+		if len(fields) == 0 {
+			continue
+		}
+		regex, err := regexp.Compile(codeownersRegex(fields[0]))
+		if err != nil {
+			continue
+		}
+		rules = append(rules, CodeownersRule{Pattern: fields[0], Owners: fields[1:], regex: regex})
+	}
+	return rules
+}
+
+// stripCodeownersComment removes a comment: a "#" starting the line or a word, unless escaped ("\#")
+func stripCodeownersComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\':
+			i++
+		case line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// Owners returns the owners of a file: the last matching rule wins, as on GitHub
+func (c Codeowners) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(c) - 1; i >= 0; i-- {
+		if c[i].regex.MatchString(path) {
+			return c[i].Owners
+		}
+	}
+	return nil
+}
+
+// codeownersRegex converts a CODEOWNERS (gitignore-style) pattern to a regular expression
+func codeownersRegex(pattern string) string {
+	// Patterns with a slash (other than a trailing one) are relative to the root, the others match at any depth
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" || pattern == "*" {
+		// "*" and "/" own every file
+		return "^.*$"
+	}
+
+	var b strings.Builder
+	wildcard := false // in the last segment
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			// Escaped character ("\#", "\*")
+			i++
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		case c == '/':
+			wildcard = false
+			b.WriteByte('/')
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+			wildcard = true
+		case c == '*':
+			b.WriteString("[^/]*")
+			wildcard = true
+		case c == '?':
+			b.WriteString("[^/]")
+			wildcard = true
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	prefix := "^"
+	if !anchored {
+		prefix = "^(.*/)?"
+	}
+	switch {
+	case directory:
+		// "apps/" only matches a directory: the files under it
+		return prefix + b.String() + "/.*$"
+	case !wildcard:
+		// "apps" matches a file, or everything under a directory. With a wildcard in the last segment
+		// ("docs/*", "*.js") the pattern only matches at its own depth
+		return prefix + b.String() + "(/.*)?$"
+	}
+	return prefix + b.String() + "$"
+}
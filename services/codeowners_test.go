@@ -0,0 +1,84 @@
+package services
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestCodeownersRegex(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*", "main.go", true},
+		{"*", "cmd/root.go", true},
+		{"/", "cmd/root.go", true},
+		{"*.js", "app.js", true},
+		{"*.js", "web/src/app.js", true},
+		{"*.js", "app.jsx", false},
+		{"*.js", "app.js/index.html", false},
+		{"apps", "apps", true},
+		{"apps", "apps/web/main.go", true},
+		{"apps", "src/apps/main.go", true},
+		{"apps", "myapps/main.go", false},
+		{"apps/", "apps/main.go", true},
+		{"apps/", "src/apps/main.go", true},
+		{"apps/", "apps", false},
+		{"/build/logs/", "build/logs/today.log", true},
+		{"/build/logs/", "src/build/logs/today.log", false},
+		{"docs/*", "docs/index.md", true},
+		{"docs/*", "docs/guides/index.md", false},
+		{"docs/*", "src/docs/index.md", false},
+		{"**/logs", "logs/today.log", true},
+		{"**/logs", "build/logs/today.log", true},
+		{"apps/**/test", "apps/test/a.go", true},
+		{"apps/**/test", "apps/web/unit/test/a.go", true},
+		{"apps/**", "apps/web/main.go", true},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+		{"file?.txt", "dir/file/.txt", false},
+		{`\#notes`, "#notes", true},
+		{"a.b", "axb", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			regex := regexp.MustCompile(codeownersRegex(tt.pattern))
+			if got := regex.MatchString(tt.path); got != tt.want {
+				t.Errorf("%s matches %s = %v, want %v (regex %s)", tt.pattern, tt.path, got, tt.want, regex)
+			}
+		})
+	}
+}
+
+func TestCodeownersOwners(t *testing.T) {
+	codeowners := ParseCodeowners(`# Default owners
+*           @org/everyone
+*.go        @org/gophers   # inline comment
+/docs/      @org/writers docs@example.com
+\#hash      @org/hash
+/docs/api/  # no owners: unowned
+`)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@org/everyone"}},
+		{"/cmd/root.go", []string{"@org/gophers"}},
+		{"docs/index.md", []string{"@org/writers", "docs@example.com"}},
+		{"docs/tools/gen.go", []string{"@org/writers", "docs@example.com"}},
+		{"#hash", []string{"@org/hash"}},
+		{"docs/api/spec.yaml", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := codeowners.Owners(tt.path); !slices.Equal(got, tt.want) {
+				t.Errorf("Owners(%s) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +15,8 @@ import (
 )
 
 var client *api.RESTClient
+var graphQLClient *api.GraphQLClient
+var conditionalClient *api.RESTClient
 
 func init() {
 	initRestClient()
@@ -70,8 +74,13 @@ func getPages[T any](path string, target *T) (next string, err error) {
 		return "", err
 	}
 
+	return nextPage(resp.Header), nil
+}
+
+// nextPage returns the URL of the next page from the Link header (empty on the last page)
+func nextPage(header http.Header) (next string) {
 	// Robust Link Header Parsing
-	linkHeader, ok := resp.Header["Link"]
+	linkHeader, ok := header["Link"]
 	if ok {
 		links := strings.Split(linkHeader[0], ",")
 		for _, v := range links {
@@ -88,8 +97,82 @@ func getPages[T any](path string, target *T) (next string, err error) {
 			}
 		}
 	}
+	return next
+}
+
+// etagKey carries the If-None-Match value of a request in its context
+type etagKey struct{}
+
+// etagTransport sets If-None-Match from the request context, as the REST client can't set per request headers
+type etagTransport struct {
+	http.RoundTripper
+}
+
+func (t etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if etag, _ := req.Context().Value(etagKey{}).(string); etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", etag)
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// conditionalGet fetches a page with If-None-Match. When the resource did not change since etag
+// was returned, the API answers 304 (which doesn't count against the rate limit) and notModified is set.
+func conditionalGet(path, etag string) (body []byte, newETag, next string, notModified bool, err error) {
+	if conditionalClient == nil {
+		if conditionalClient, err = api.NewRESTClient(api.ClientOptions{Transport: etagTransport{http.DefaultTransport}}); err != nil {
+			return nil, "", "", false, err
+		}
+	}
+
+	ctx := context.WithValue(context.Background(), etagKey{}, etag)
+	resp, err := conditionalClient.RequestWithContext(ctx, "GET", path, nil)
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotModified {
+		return nil, etag, "", true, nil
+	}
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	return body, resp.Header.Get("ETag"), nextPage(resp.Header), false, nil
+}
+
+// fetchAllPages retrieves every page of a listing silently (for automation)
+func fetchAllPages[T any](path string) ([]T, error) {
+	var all []T
+	for {
+		var page []T
+		next, err := getPages(path, &page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if next == "" {
+			return all, nil
+		}
+		path = next
+	}
+}
 
-	return next, nil
+// graphQL runs a GraphQL query. The dependency graph preview header is always sent,
+// as the dependencyGraphManifests connection requires it.
+func graphQL(query string, variables map[string]interface{}, response interface{}) error {
+	if graphQLClient == nil {
+		var err error
+		graphQLClient, err = api.NewGraphQLClient(api.ClientOptions{
+			Headers: map[string]string{"Accept": "application/vnd.github.hawkgirl-preview+json"},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return graphQLClient.Do(query, variables, response)
 }
 
 // Add this to services/core.go
@@ -117,3 +200,27 @@ func patch(path string, body interface{}) error {
 
 	return api.HandleHTTPError(resp)
 }
+
+// post sends a JSON body and decodes the JSON response (when response is not nil)
+func post(path string, body interface{}, response interface{}) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return client.Post(path, bytes.NewReader(jsonBody), response)
+}
+
+// put sends a JSON body with PUT and decodes the JSON response (when response is not nil)
+func put(path string, body interface{}, response interface{}) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return client.Put(path, bytes.NewReader(jsonBody), response)
+}
+
+// isNotFound reports whether err is a 404 from the REST API
+func isNotFound(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == 404
+}
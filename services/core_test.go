@@ -0,0 +1,56 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestConditionalGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v2"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v2"`)
+		w.Header().Set("Link", `<`+"http://"+r.Host+`/alerts?page=2>; rel="next", <`+"http://"+r.Host+`/alerts?page=3>; rel="last"`)
+		w.Write([]byte(`[{"number":1}]`))
+	}))
+	defer server.Close()
+
+	previous := conditionalClient
+	defer func() { conditionalClient = previous }()
+	var err error
+	conditionalClient, err = api.NewRESTClient(api.ClientOptions{Host: "github.com", AuthToken: "token", Transport: etagTransport{http.DefaultTransport}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		etag        string
+		body        string
+		newETag     string
+		next        string
+		notModified bool
+	}{
+		{"first request", "", `[{"number":1}]`, `"v2"`, server.URL + "/alerts?page=2", false},
+		{"changed", `"v1"`, `[{"number":1}]`, `"v2"`, server.URL + "/alerts?page=2", false},
+		{"not modified", `"v2"`, "", `"v2"`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, newETag, next, notModified, err := conditionalGet(server.URL+"/alerts", tt.etag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != tt.body || newETag != tt.newETag || next != tt.next || notModified != tt.notModified {
+				t.Errorf("conditionalGet = (%q, %q, %q, %v), want (%q, %q, %q, %v)",
+					body, newETag, next, notModified, tt.body, tt.newETag, tt.next, tt.notModified)
+			}
+		})
+	}
+}
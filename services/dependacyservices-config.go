@@ -0,0 +1,424 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"go.yaml.in/yaml/v3"
+)
+
+// Results of a dependabot.yml audit
+const (
+	DependabotConfigOK         = "ok"
+	DependabotConfigMissing    = "missing"
+	DependabotConfigInvalid    = "invalid"
+	DependabotConfigIncomplete = "incomplete"
+	DependabotConfigUnknown    = "unknown" // the configuration or the dependency graph couldn't be read
+)
+
+// DependabotEcosystems are the accepted values of package-ecosystem
+var DependabotEcosystems = []string{
+	"bun", "bundler", "cargo", "composer", "devcontainers", "docker", "docker-compose", "dotnet-sdk", "elm",
+	"github-actions", "gitsubmodule", "gomod", "gradle", "helm", "maven", "mix", "npm", "nuget", "pip",
+	"pub", "swift", "terraform", "uv",
+}
+
+// DependabotIntervals are the accepted values of schedule.interval
+var DependabotIntervals = []string{"daily", "weekly", "monthly", "quarterly", "semiannually", "yearly", "cron"}
+
+var dependabotConfigFiles = []string{".github/dependabot.yml", ".github/dependabot.yaml"}
+
+// manifestEcosystems maps the (lowercase) manifest and lock file names of the dependency graph to package-ecosystem
+var manifestEcosystems = map[string]string{
+	"package.json":             "npm",
+	"package-lock.json":        "npm",
+	"npm-shrinkwrap.json":      "npm",
+	"yarn.lock":                "npm",
+	"pnpm-lock.yaml":           "npm",
+	"go.mod":                   "gomod",
+	"go.sum":                   "gomod",
+	"requirements.txt":         "pip",
+	"pipfile":                  "pip",
+	"pipfile.lock":             "pip",
+	"pyproject.toml":           "pip",
+	"poetry.lock":              "pip",
+	"setup.py":                 "pip",
+	"pom.xml":                  "maven",
+	"build.gradle":             "gradle",
+	"build.gradle.kts":         "gradle",
+	"gradle.lockfile":          "gradle",
+	"gemfile":                  "bundler",
+	"gemfile.lock":             "bundler",
+	"composer.json":            "composer",
+	"composer.lock":            "composer",
+	"packages.config":          "nuget",
+	"packages.lock.json":       "nuget",
+	"directory.packages.props": "nuget",
+	"cargo.toml":               "cargo",
+	"cargo.lock":               "cargo",
+	"pubspec.yaml":             "pub",
+	"pubspec.lock":             "pub",
+	"package.swift":            "swift",
+	"package.resolved":         "swift",
+	"mix.exs":                  "mix",
+	"mix.lock":                 "mix",
+	"dockerfile":               "docker",
+}
+
+// manifestEcosystem returns the Dependabot ecosystem and directory of a dependency graph manifest
+func manifestEcosystem(file string) (string, string) {
+	file = strings.TrimPrefix(file, "/")
+	dir := "/" + path.Dir(file)
+	if dir == "/." {
+		dir = "/"
+	}
+
+	name := strings.ToLower(path.Base(file))
+	switch {
+	case strings.HasPrefix(file, ".github/workflows/") || name == "action.yml" || name == "action.yaml":
+		// Dependabot reads every workflow from the repository root
+		return "github-actions", "/"
+	case manifestEcosystems[name] != "":
+		return manifestEcosystems[name], dir
+	case strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt"):
+		return "pip", dir
+	case strings.HasSuffix(name, ".csproj"), strings.HasSuffix(name, ".vbproj"), strings.HasSuffix(name, ".fsproj"):
+		return "nuget", dir
+	case strings.HasSuffix(name, ".tf"):
+		return "terraform", dir
+	}
+	return "", ""
+}
+
+// DetectDependabotEcosystems lists the ecosystems of the dependency graph manifests and their directories
+// Docs: GraphQL repository.dependencyGraphManifests
+func (d *DependencyServices) DetectDependabotEcosystems(owner, repo string) (map[string][]string, error) {
+	const manifestsQuery = `query($owner: String!, $name: String!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    dependencyGraphManifests(first: 100, after: $after) {
+      pageInfo { hasNextPage endCursor }
+      nodes { filename }
+    }
+  }
+}`
+
+	found := map[string]map[string]bool{}
+	variables := map[string]interface{}{"owner": owner, "name": repo, "after": nil}
+	for {
+		var response struct {
+			Repository struct {
+				DependencyGraphManifests model.DependencyGraphManifests `json:"dependencyGraphManifests"`
+			} `json:"repository"`
+		}
+		if err := graphQL(manifestsQuery, variables, &response); err != nil {
+			return nil, err
+		}
+
+		connection := response.Repository.DependencyGraphManifests
+		for _, manifest := range connection.Nodes {
+			ecosystem, dir := manifestEcosystem(manifest.Filename)
+			if ecosystem == "" {
+				continue
+			}
+			if found[ecosystem] == nil {
+				found[ecosystem] = map[string]bool{}
+			}
+			found[ecosystem][dir] = true
+		}
+
+		if !connection.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = connection.PageInfo.EndCursor
+	}
+
+	ecosystems := map[string][]string{}
+	for ecosystem, dirs := range found {
+		ecosystems[ecosystem] = sortedKeys(dirs)
+	}
+	return ecosystems, nil
+}
+
+// FetchDependabotConfig returns the content and path of the dependabot.yml of a repository (nil when there is none)
+func (d *DependencyServices) FetchDependabotConfig(owner, repo string) ([]byte, string, error) {
+	for _, file := range dependabotConfigFiles {
+		content, err := GetRepositoryServices().GetFileContent(owner, repo, file, "")
+		if err == nil {
+			return content, file, nil
+		}
+		if !isNotFound(err) {
+			return nil, "", err
+		}
+	}
+	return nil, "", nil
+}
+
+// ValidateDependabotConfig checks a dependabot.yml against the configuration schema (version 2)
+func ValidateDependabotConfig(content []byte) (*model.DependabotConfig, []string) {
+	config := &model.DependabotConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil {
+		// yaml reports one error per line: keep them on a single line for the table
+		return nil, []string{strings.Join(strings.Fields(err.Error()), " ")}
+	}
+
+	problems := []string{}
+	if config.Version != 2 {
+		problems = append(problems, fmt.Sprintf("version must be 2 (found %d)", config.Version))
+	}
+	if len(config.Updates) == 0 {
+		problems = append(problems, "no updates configured")
+	}
+
+	seen := map[string]bool{}
+	for i, u := range config.Updates {
+		entry := fmt.Sprintf("updates[%d]", i)
+		if u.PackageEcosystem == "" {
+			problems = append(problems, entry+": package-ecosystem is required")
+		} else if !slices.Contains(DependabotEcosystems, u.PackageEcosystem) {
+			problems = append(problems, fmt.Sprintf("%s: unknown package-ecosystem '%s'", entry, u.PackageEcosystem))
+		}
+
+		switch {
+		case u.Directory == "" && len(u.Directories) == 0:
+			problems = append(problems, entry+": directory or directories is required")
+		case u.Directory != "" && len(u.Directories) > 0:
+			problems = append(problems, entry+": use either directory or directories, not both")
+		}
+
+		switch {
+		case u.Schedule.Interval == "":
+			problems = append(problems, entry+": schedule.interval is required")
+		case !slices.Contains(DependabotIntervals, u.Schedule.Interval):
+			problems = append(problems, fmt.Sprintf("%s: unknown schedule.interval '%s'", entry, u.Schedule.Interval))
+		case u.Schedule.Interval == "cron" && u.Schedule.Cronjob == "":
+			problems = append(problems, entry+": schedule.cronjob is required with the cron interval")
+		}
+
+		for _, dir := range updateDirectories(u) {
+			key := fmt.Sprintf("%s|%s|%v", u.PackageEcosystem, dir, u.TargetBranch)
+			if seen[key] {
+				problems = append(problems, fmt.Sprintf("%s: duplicate entry for %s in %s", entry, u.PackageEcosystem, dir))
+			}
+			seen[key] = true
+		}
+	}
+	return config, problems
+}
+
+// AuditDependabotConfig validates the dependabot.yml of a repository and compares it with the ecosystems
+// found in its dependency graph
+func (d *DependencyServices) AuditDependabotConfig(owner, repo string) model.DependabotConfigAudit {
+	audit := model.DependabotConfigAudit{Repository: owner + "/" + repo, Errors: []string{}, Missing: []string{}}
+
+	content, file, err := d.FetchDependabotConfig(owner, repo)
+	if err != nil {
+		audit.Status = DependabotConfigUnknown
+		audit.Errors = append(audit.Errors, err.Error())
+		return audit
+	}
+	audit.File = file
+
+	var config *model.DependabotConfig
+	if content != nil {
+		config, audit.Errors = ValidateDependabotConfig(content)
+	}
+
+	// Only the errors of the configuration make it invalid
+	invalid := len(audit.Errors) > 0
+
+	ecosystems, graphErr := d.DetectDependabotEcosystems(owner, repo)
+	if graphErr != nil {
+		audit.Errors = append(audit.Errors, "dependency graph: "+graphErr.Error())
+	}
+	for _, ecosystem := range sortedKeys(toSet(ecosystems)) {
+		for _, dir := range ecosystems[ecosystem] {
+			if config == nil || !configCovers(config, ecosystem, dir) {
+				audit.Missing = append(audit.Missing, ecosystem+" "+dir)
+			}
+		}
+	}
+
+	switch {
+	case content == nil:
+		audit.Status = DependabotConfigMissing
+	case invalid:
+		audit.Status = DependabotConfigInvalid
+	case graphErr != nil:
+		audit.Status = DependabotConfigUnknown
+	case len(audit.Missing) > 0:
+		audit.Status = DependabotConfigIncomplete
+	default:
+		audit.Status = DependabotConfigOK
+	}
+	return audit
+}
+
+// AuditDependabotConfigs audits a repository, or every (non archived) repository of an organization.
+// Returns the number of repositories whose configuration is not ok.
+func (d *DependencyServices) AuditDependabotConfigs(target string, jsonOutput bool) (int, error) {
+	var audits []model.DependabotConfigAudit
+
+	if owner, repo, found := strings.Cut(target, "/"); found {
+		audits = append(audits, d.AuditDependabotConfig(owner, repo))
+	} else {
+		repos, err := GetRepositoryServices().FetchAllForOrg(target)
+		if err != nil {
+			return 0, err
+		}
+
+		fmt.Fprintf(os.Stderr, "Auditing the Dependabot configuration of %d repositories. This may take a while...\n", len(repos))
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		semaphore := make(chan struct{}, 5)
+		for _, r := range repos {
+			if r.Archived {
+				continue
+			}
+			wg.Add(1)
+			go func(repoName string) {
+				defer wg.Done()
+				semaphore <- struct{}{}        // Acquire
+				defer func() { <-semaphore }() // Release
+
+				audit := d.AuditDependabotConfig(target, repoName)
+				mu.Lock()
+				audits = append(audits, audit)
+				mu.Unlock()
+			}(r.Name)
+		}
+		wg.Wait()
+	}
+
+	sort.Slice(audits, func(i, j int) bool { return audits[i].Repository < audits[j].Repository })
+
+	failing := 0
+	for _, a := range audits {
+		if a.Status != DependabotConfigOK {
+			failing++
+		}
+	}
+
+	if jsonOutput {
+		return failing, jsonLister(audits)
+	}
+
+	tp, err := getTablePrinter()
+	if err != nil {
+		return 0, err
+	}
+	tp.AddHeader([]string{"Repository", "Status", "File", "Errors", "Missing Updates"})
+	for _, a := range audits {
+		file := a.File
+		if file == "" {
+			file = "-"
+		}
+		tp.AddField(a.Repository)
+		tp.AddField(a.Status)
+		tp.AddField(file)
+		tp.AddField(strings.Join(a.Errors, "; "))
+		tp.AddField(strings.Join(a.Missing, ", "))
+		tp.EndRow()
+	}
+	if err := tp.Render(); err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("%d of %d repositories need attention\n", failing, len(audits))
+	return failing, nil
+}
+
+// GenerateDependabotConfig proposes a dependabot.yml with one update entry per ecosystem of the
+// dependency graph, and prints it or writes it to output
+func (d *DependencyServices) GenerateDependabotConfig(owner, repo, interval, output string) error {
+	if !slices.Contains(DependabotIntervals, interval) || interval == "cron" {
+		return fmt.Errorf("invalid interval '%s' (expected daily, weekly, monthly, quarterly, semiannually or yearly)", interval)
+	}
+
+	ecosystems, err := d.DetectDependabotEcosystems(owner, repo)
+	if err != nil {
+		return err
+	}
+	if len(ecosystems) == 0 {
+		return fmt.Errorf("no supported manifests found in the dependency graph of %s/%s", owner, repo)
+	}
+
+	config := model.DependabotConfig{Version: 2}
+	for _, ecosystem := range sortedKeys(toSet(ecosystems)) {
+		update := model.DependabotUpdate{
+			PackageEcosystem: ecosystem,
+			Schedule:         model.DependabotSchedule{Interval: interval},
+		}
+		if dirs := ecosystems[ecosystem]; len(dirs) == 1 {
+			update.Directory = dirs[0]
+		} else {
+			update.Directories = dirs
+		}
+		config.Updates = append(config.Updates, update)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "# Proposed from the dependency graph of %s/%s\n", owner, repo)
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return err
+	}
+	encoder.Close()
+
+	if output == "" {
+		fmt.Print(out.String())
+		return nil
+	}
+	if err := os.WriteFile(output, out.Bytes(), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Dependabot configuration with %d ecosystems saved to %s\n", len(config.Updates), output)
+	return nil
+}
+
+// configCovers checks if an update entry handles the ecosystem in the directory (directories may use globs)
+func configCovers(config *model.DependabotConfig, ecosystem, dir string) bool {
+	for _, u := range config.Updates {
+		if u.PackageEcosystem != ecosystem {
+			continue
+		}
+		for _, pattern := range updateDirectories(u) {
+			pattern = "/" + strings.Trim(pattern, "/")
+			switch {
+			case pattern == dir, pattern == "/**":
+				return true
+			case strings.HasSuffix(pattern, "/**") && strings.HasPrefix(dir+"/", strings.TrimSuffix(pattern, "**")):
+				return true
+			}
+			if ok, _ := path.Match(pattern, dir); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func updateDirectories(u model.DependabotUpdate) []string {
+	if u.Directory != "" {
+		return []string{u.Directory}
+	}
+	return u.Directories
+}
+
+func toSet[V any](m map[string]V) map[string]bool {
+	set := make(map[string]bool, len(m))
+	for k := range m {
+		set[k] = true
+	}
+	return set
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// DependencyReviewSeverities are the advisory severities of the dependency graph, from lowest to highest
+var DependencyReviewSeverities = []string{"low", "moderate", "high", "critical"}
+
+// CompareDependencies returns the dependency changes between two refs ("base...head")
+// Docs: GET /repos/{owner}/{repo}/dependency-graph/compare/{basehead}
+func (d *DependencyServices) CompareDependencies(owner, repo, basehead string) ([]model.DependencyChange, error) {
+	if !strings.Contains(basehead, "...") {
+		return nil, fmt.Errorf("invalid range '%s' (expected base...head)", basehead)
+	}
+
+	changes := []model.DependencyChange{}
+	err := client.Get(fmt.Sprintf("repos/%s/%s/dependency-graph/compare/%s", owner, repo, basehead), &changes)
+	return changes, err
+}
+
+// DiffDependencies shows the packages added, removed and updated between two refs with their licenses and
+// vulnerabilities. Returns the number of vulnerabilities introduced at or above failOnSeverity (0 when empty).
+func (d *DependencyServices) DiffDependencies(owner, repo, basehead, failOnSeverity string, jsonOutput bool) (int, error) {
+	threshold := -1
+	if failOnSeverity != "" {
+		threshold = severityRank(failOnSeverity)
+		if threshold < 0 {
+			return 0, fmt.Errorf("invalid severity '%s' (expected one of %s)", failOnSeverity, strings.Join(DependencyReviewSeverities, ", "))
+		}
+	}
+
+	changes, err := d.CompareDependencies(owner, repo, basehead)
+	if err != nil {
+		return 0, err
+	}
+
+	entries := mergeDependencyChanges(changes)
+
+	failing := 0
+	if threshold >= 0 {
+		for _, e := range entries {
+			if e.Change == "removed" {
+				continue
+			}
+			for _, v := range e.Vulnerabilities {
+				if severityRank(v.Severity) >= threshold {
+					failing++
+				}
+			}
+		}
+	}
+
+	if jsonOutput {
+		return failing, jsonLister(entries)
+	}
+
+	if len(entries) > 0 {
+		tp, err := getTablePrinter()
+		if err != nil {
+			return 0, err
+		}
+		tp.AddHeader([]string{"Change", "Manifest", "Package", "Version", "License", "Scope", "Vulnerabilities"})
+		for _, e := range entries {
+			version := e.ToVersion
+			switch e.Change {
+			case "removed":
+				version = e.FromVersion
+			case "updated":
+				version = e.FromVersion + " -> " + e.ToVersion
+			}
+			license := e.License
+			if license == "" {
+				license = "-"
+			}
+
+			tp.AddField(e.Change)
+			tp.AddField(e.Manifest)
+			tp.AddField(e.Ecosystem + ":" + e.Name)
+			tp.AddField(version)
+			tp.AddField(license)
+			tp.AddField(e.Scope)
+			tp.AddField(formatDependencyVulnerabilities(e))
+			tp.EndRow()
+		}
+		if err := tp.Render(); err != nil {
+			return 0, err
+		}
+	}
+
+	counts := map[string]int{}
+	for _, e := range entries {
+		counts[e.Change]++
+	}
+	fmt.Printf("%d added, %d removed, %d updated between %s\n", counts["added"], counts["removed"], counts["updated"], basehead)
+	if failing > 0 {
+		fmt.Printf("%d vulnerabilities at or above '%s' severity introduced\n", failing, failOnSeverity)
+	}
+	return failing, nil
+}
+
+// mergeDependencyChanges turns a removed + added pair of the same package in the same manifest into an update.
+// A manifest may hold several versions of a package: each added version takes one of the removed ones.
+func mergeDependencyChanges(changes []model.DependencyChange) []model.DependencyDiffEntry {
+	key := func(c model.DependencyChange) string {
+		return c.Manifest + "|" + c.Ecosystem + "|" + c.Name
+	}
+
+	removed := map[string][]model.DependencyChange{}
+	for _, c := range changes {
+		if c.ChangeType == "removed" {
+			removed[key(c)] = append(removed[key(c)], c)
+		}
+	}
+
+	entries := []model.DependencyDiffEntry{}
+	for _, c := range changes {
+		if c.ChangeType != "added" {
+			continue
+		}
+		entry := model.DependencyDiffEntry{
+			Change:          "added",
+			Manifest:        c.Manifest,
+			Ecosystem:       c.Ecosystem,
+			Name:            c.Name,
+			ToVersion:       c.Version,
+			License:         c.License,
+			Scope:           c.Scope,
+			Vulnerabilities: c.Vulnerabilities,
+		}
+		if old := removed[key(c)]; len(old) > 0 {
+			entry.Change = "updated"
+			entry.FromVersion = old[0].Version
+			removed[key(c)] = old[1:]
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, left := range removed {
+		for _, c := range left {
+			entries = append(entries, model.DependencyDiffEntry{
+				Change:          "removed",
+				Manifest:        c.Manifest,
+				Ecosystem:       c.Ecosystem,
+				Name:            c.Name,
+				FromVersion:     c.Version,
+				License:         c.License,
+				Scope:           c.Scope,
+				Vulnerabilities: c.Vulnerabilities,
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Manifest != entries[j].Manifest {
+			return entries[i].Manifest < entries[j].Manifest
+		}
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		if entries[i].FromVersion != entries[j].FromVersion {
+			return compareVersions(entries[i].FromVersion, entries[j].FromVersion) < 0
+		}
+		return compareVersions(entries[i].ToVersion, entries[j].ToVersion) < 0
+	})
+	return entries
+}
+
+// formatDependencyVulnerabilities lists the advisories of a change; for removed packages they are fixed
+func formatDependencyVulnerabilities(e model.DependencyDiffEntry) string {
+	if len(e.Vulnerabilities) == 0 {
+		return "-"
+	}
+	advisories := []string{}
+	for _, v := range e.Vulnerabilities {
+		advisories = append(advisories, fmt.Sprintf("%s (%s)", v.AdvisoryGHSAId, v.Severity))
+	}
+	if e.Change == "removed" {
+		return "fixed: " + strings.Join(advisories, ", ")
+	}
+	return strings.Join(advisories, ", ")
+}
+
+func severityRank(severity string) int {
+	for i, s := range DependencyReviewSeverities {
+		if strings.EqualFold(s, severity) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,443 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// repoSBOM pairs a repository with its parsed SBOM (or the error fetching it)
+type repoSBOM struct {
+	repository string
+	document   *model.SPDXDocument
+	err        error
+}
+
+// fetchSBOMs retrieves the SBOM of a single repository ("owner/repo") or of every repository of an organization
+func (d *DependencyServices) fetchSBOMs(target string) ([]repoSBOM, error) {
+	if owner, repo, found := strings.Cut(target, "/"); found {
+		_, doc, err := d.FetchSBOM(owner, repo)
+		return []repoSBOM{{repository: target, document: doc, err: err}}, nil
+	}
+
+	repos, err := GetRepositoryServices().FetchAllForOrg(target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Progress goes to stderr so JSON output stays parseable
+	fmt.Fprintf(os.Stderr, "Reading the dependency graph of %d repositories. This may take a while...\n", len(repos))
+
+	results := make([]repoSBOM, len(repos))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, 5)
+
+	for i, repo := range repos {
+		wg.Add(1)
+		go func(index int, repoName string) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			_, doc, err := d.FetchSBOM(target, repoName)
+			results[index] = repoSBOM{repository: target + "/" + repoName, document: doc, err: err}
+		}(i, repo.Name)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// sbomPackages returns the dependencies of an SBOM (every package but the one describing the repository itself)
+func sbomPackages(repository string, doc *model.SPDXDocument) []model.PackageUsage {
+	root := map[string]bool{}
+	for _, id := range doc.DocumentDescribes {
+		root[id] = true
+	}
+
+	var usages []model.PackageUsage
+	for _, p := range doc.Packages {
+		if root[p.SPDXID] {
+			continue
+		}
+
+		usage := model.PackageUsage{
+			Repository: repository,
+			Name:       p.Name,
+			Version:    p.VersionInfo,
+			License:    SPDXPackageLicense(p),
+			Purl:       SPDXPackagePurl(p),
+		}
+		if usage.Purl != "" {
+			kind, namespace, name, _ := parsePurl(usage.Purl)
+			usage.Ecosystem = kind
+			usage.Name = name
+			if namespace != "" {
+				separator := "/"
+				if kind == "maven" {
+					separator = ":"
+				}
+				usage.Name = namespace + separator + name
+			}
+		} else if ecosystem, name, found := strings.Cut(p.Name, ":"); found {
+			usage.Ecosystem = ecosystem
+			usage.Name = name
+		}
+		if usage.Ecosystem == "" {
+			usage.Ecosystem = "unknown"
+		}
+		usages = append(usages, usage)
+	}
+	return usages
+}
+
+// packageMatches compares a query with a package name, with or without its group/namespace
+// (e.g. "log4j-core" matches "org.apache.logging.log4j:log4j-core")
+func packageMatches(name, query string) bool {
+	name = strings.ToLower(name)
+	query = strings.ToLower(query)
+	return name == query || strings.HasSuffix(name, ":"+query) || strings.HasSuffix(name, "/"+query)
+}
+
+// SearchPackage lists every repository (and manifest) of an organization using a package,
+// optionally limited to the versions in versionRange (e.g. "<2.17").
+func (d *DependencyServices) SearchPackage(target, pkg, versionRange string, jsonOutput bool) error {
+	if _, err := versionInRange("0", versionRange); err != nil {
+		return err
+	}
+
+	sboms, err := d.fetchSBOMs(target)
+	if err != nil {
+		return err
+	}
+
+	matches := []model.PackageUsage{}
+	skipped, failed := 0, 0
+	for _, s := range sboms {
+		switch {
+		case isNotFound(s.err):
+			// The dependency graph is disabled (or the repository is empty)
+			skipped++
+			continue
+		case s.err != nil:
+			fmt.Fprintf(os.Stderr, "Unable to read the dependency graph of %s: %v\n", s.repository, s.err)
+			failed++
+			continue
+		}
+		for _, usage := range sbomPackages(s.repository, s.document) {
+			if !packageMatches(usage.Name, pkg) {
+				continue
+			}
+			if ok, _ := versionInRange(usage.Version, versionRange); !ok {
+				continue
+			}
+			matches = append(matches, usage)
+		}
+	}
+
+	// The SBOM has no manifest paths: look them up for the affected repositories only
+	manifests := map[string][]string{}
+	manifestsFailed := 0
+	for i, usage := range matches {
+		if _, done := manifests[usage.Repository]; !done {
+			owner, repo, _ := strings.Cut(usage.Repository, "/")
+			found, err := d.FindManifestsForPackage(owner, repo, pkg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Unable to find the manifests of %s: %v\n", usage.Repository, err)
+				manifestsFailed++
+				found = []string{}
+			}
+			manifests[usage.Repository] = found
+		}
+		matches[i].Manifests = manifests[usage.Repository]
+	}
+
+	// The results are still listed, but they are incomplete
+	var incomplete []error
+	if failed > 0 {
+		incomplete = append(incomplete, fmt.Errorf("unable to read the dependency graph of %d of %d repositories", failed, len(sboms)))
+	}
+	if manifestsFailed > 0 {
+		incomplete = append(incomplete, fmt.Errorf("unable to find the manifests of %d of %d repositories", manifestsFailed, len(manifests)))
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Repository != matches[j].Repository {
+			return matches[i].Repository < matches[j].Repository
+		}
+		return compareVersions(matches[i].Version, matches[j].Version) < 0
+	})
+
+	if jsonOutput {
+		if err := jsonLister(matches); err != nil {
+			return err
+		}
+		return errors.Join(incomplete...)
+	}
+
+	tp, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	tp.AddHeader([]string{"Repository", "Ecosystem", "Package", "Version", "License", "Manifests"})
+	for _, m := range matches {
+		license := m.License
+		if license == "" {
+			license = "-"
+		}
+		tp.AddField(m.Repository)
+		tp.AddField(m.Ecosystem)
+		tp.AddField(m.Name)
+		tp.AddField(m.Version)
+		tp.AddField(license)
+		tp.AddField(strings.Join(m.Manifests, ","))
+		tp.EndRow()
+	}
+	if err := tp.Render(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d repositories affected (%d scanned, %d without dependency graph, %d failed)\n", len(manifests), len(sboms), skipped, failed)
+	return errors.Join(incomplete...)
+}
+
+// FindManifestsForPackage returns the manifest files of a repository that declare the package
+// Docs: GraphQL repository.dependencyGraphManifests
+func (d *DependencyServices) FindManifestsForPackage(owner, repo, pkg string) ([]string, error) {
+	const manifestsQuery = `query($owner: String!, $name: String!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    dependencyGraphManifests(first: 20, after: $after) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        id
+        filename
+        dependencies(first: 100) {
+          pageInfo { hasNextPage endCursor }
+          nodes { packageName packageManager requirements }
+        }
+      }
+    }
+  }
+}`
+
+	found := []string{}
+	variables := map[string]interface{}{"owner": owner, "name": repo, "after": nil}
+
+	for {
+		var response struct {
+			Repository struct {
+				DependencyGraphManifests model.DependencyGraphManifests `json:"dependencyGraphManifests"`
+			} `json:"repository"`
+		}
+		if err := graphQL(manifestsQuery, variables, &response); err != nil {
+			return nil, err
+		}
+
+		connection := response.Repository.DependencyGraphManifests
+		for _, manifest := range connection.Nodes {
+			declares, err := d.manifestDeclares(manifest, pkg)
+			if err != nil {
+				return nil, err
+			}
+			if declares {
+				found = append(found, manifest.Filename)
+			}
+		}
+
+		if !connection.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = connection.PageInfo.EndCursor
+	}
+	return found, nil
+}
+
+// manifestDeclares checks the dependencies of a manifest, paging through them when needed
+func (d *DependencyServices) manifestDeclares(manifest model.DependencyGraphManifest, pkg string) (bool, error) {
+	const dependenciesQuery = `query($id: ID!, $after: String) {
+  node(id: $id) {
+    ... on DependencyGraphManifest {
+      dependencies(first: 100, after: $after) {
+        pageInfo { hasNextPage endCursor }
+        nodes { packageName packageManager requirements }
+      }
+    }
+  }
+}`
+
+	dependencies := manifest.Dependencies
+	for {
+		for _, dep := range dependencies.Nodes {
+			if packageMatches(dep.PackageName, pkg) {
+				return true, nil
+			}
+		}
+		if !dependencies.PageInfo.HasNextPage {
+			return false, nil
+		}
+
+		var response struct {
+			Node struct {
+				Dependencies model.DependencyGraphDependencies `json:"dependencies"`
+			} `json:"node"`
+		}
+		variables := map[string]interface{}{"id": manifest.ID, "after": dependencies.PageInfo.EndCursor}
+		if err := graphQL(dependenciesQuery, variables, &response); err != nil {
+			return false, err
+		}
+		dependencies = response.Node.Dependencies
+	}
+}
+
+// Inventory aggregates the packages of an organization by ecosystem and license,
+// or lists every package with its versions, licenses and repositories when byPackage is set.
+func (d *DependencyServices) Inventory(target string, byPackage bool, jsonOutput bool) error {
+	sboms, err := d.fetchSBOMs(target)
+	if err != nil {
+		return err
+	}
+
+	type group struct {
+		ecosystem, name, license  string
+		packages, repos, versions map[string]bool
+		licenses                  map[string]bool
+	}
+	groups := map[string]*group{}
+	skipped, failed := 0, 0
+
+	for _, s := range sboms {
+		switch {
+		case isNotFound(s.err):
+			// The dependency graph is disabled (or the repository is empty)
+			skipped++
+			continue
+		case s.err != nil:
+			fmt.Fprintf(os.Stderr, "Unable to read the dependency graph of %s: %v\n", s.repository, s.err)
+			failed++
+			continue
+		}
+		for _, usage := range sbomPackages(s.repository, s.document) {
+			license := usage.License
+			if license == "" {
+				license = "unknown"
+			}
+
+			key := usage.Ecosystem + "|" + license
+			if byPackage {
+				key = usage.Ecosystem + "|" + usage.Name
+			}
+			g, ok := groups[key]
+			if !ok {
+				g = &group{
+					ecosystem: usage.Ecosystem, name: usage.Name, license: license,
+					packages: map[string]bool{}, repos: map[string]bool{}, versions: map[string]bool{}, licenses: map[string]bool{},
+				}
+				groups[key] = g
+			}
+			g.packages[usage.Ecosystem+":"+usage.Name] = true
+			g.repos[usage.Repository] = true
+			g.versions[usage.Version] = true
+			g.licenses[license] = true
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// The inventory is still listed, but it is incomplete
+	var incomplete error
+	if failed > 0 {
+		incomplete = fmt.Errorf("unable to read the dependency graph of %d of %d repositories", failed, len(sboms))
+	}
+
+	if byPackage {
+		packages := []model.InventoryPackage{}
+		for _, k := range keys {
+			g := groups[k]
+			packages = append(packages, model.InventoryPackage{
+				Ecosystem:    g.ecosystem,
+				Name:         g.name,
+				Versions:     sortedKeys(g.versions),
+				Licenses:     sortedKeys(g.licenses),
+				Repositories: sortedKeys(g.repos),
+			})
+		}
+		if jsonOutput {
+			if err := jsonLister(packages); err != nil {
+				return err
+			}
+			return incomplete
+		}
+
+		tp, err := getTablePrinter()
+		if err != nil {
+			return err
+		}
+		tp.AddHeader([]string{"Ecosystem", "Package", "Versions", "Licenses", "Repositories"})
+		for _, p := range packages {
+			tp.AddField(p.Ecosystem)
+			tp.AddField(p.Name)
+			tp.AddField(strings.Join(p.Versions, ","))
+			tp.AddField(strings.Join(p.Licenses, ","))
+			tp.AddField(fmt.Sprintf("%d", len(p.Repositories)))
+			tp.EndRow()
+		}
+		if err := tp.Render(); err != nil {
+			return err
+		}
+	} else {
+		entries := []model.InventoryEntry{}
+		for _, k := range keys {
+			g := groups[k]
+			entries = append(entries, model.InventoryEntry{
+				Ecosystem:    g.ecosystem,
+				License:      g.license,
+				Packages:     len(g.packages),
+				Repositories: len(g.repos),
+			})
+		}
+		if jsonOutput {
+			if err := jsonLister(entries); err != nil {
+				return err
+			}
+			return incomplete
+		}
+
+		tp, err := getTablePrinter()
+		if err != nil {
+			return err
+		}
+		tp.AddHeader([]string{"Ecosystem", "License", "Packages", "Repositories"})
+		for _, e := range entries {
+			tp.AddField(e.Ecosystem)
+			tp.AddField(e.License)
+			tp.AddField(fmt.Sprintf("%d", e.Packages))
+			tp.AddField(fmt.Sprintf("%d", e.Repositories))
+			tp.EndRow()
+		}
+		if err := tp.Render(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("%d repositories scanned, %d without dependency graph, %d failed\n", len(sboms), skipped, failed)
+	return incomplete
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"github.com/spf13/viper"
+)
+
+// Reasons for a license violation
+const (
+	LicenseDenied     = "denied"
+	LicenseNotAllowed = "not allowed"
+	LicenseUnknown    = "unknown"
+)
+
+// LoadLicensePolicy reads the "licenses" section from the given file, or from the config file when empty
+func (d *DependencyServices) LoadLicensePolicy(file string) (*model.LicensePolicy, error) {
+	v := viper.GetViper()
+	if file != "" {
+		v = viper.New()
+		v.SetConfigFile(file)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	policy := &model.LicensePolicy{}
+	if err := v.UnmarshalKey("licenses", policy); err != nil {
+		return nil, err
+	}
+	if len(policy.Allow) == 0 && len(policy.Deny) == 0 {
+		return nil, fmt.Errorf("no license policy found: add a 'licenses' section with 'allow' and/or 'deny' lists to the config file")
+	}
+	return policy, nil
+}
+
+// CheckLicenses evaluates the licenses found in the SBOM of a repository (or of every repository of an
+// organization) against the policy and lists the violations. Returns the number of violations.
+func (d *DependencyServices) CheckLicenses(target string, policy *model.LicensePolicy, jsonOutput bool) (int, error) {
+	sboms, err := d.fetchSBOMs(target)
+	if err != nil {
+		return 0, err
+	}
+
+	violations := []model.LicenseViolation{}
+	skipped, failed := 0, 0
+	for _, s := range sboms {
+		switch {
+		case isNotFound(s.err):
+			// The dependency graph is disabled (or the repository is empty)
+			skipped++
+			continue
+		case s.err != nil:
+			fmt.Fprintf(os.Stderr, "Unable to read the dependency graph of %s: %v\n", s.repository, s.err)
+			failed++
+			continue
+		}
+		for _, usage := range sbomPackages(s.repository, s.document) {
+			reason := evaluateLicense(usage.License, policy)
+			if reason == "" {
+				continue
+			}
+			violations = append(violations, model.LicenseViolation{
+				Repository: usage.Repository,
+				Ecosystem:  usage.Ecosystem,
+				Package:    usage.Name,
+				Version:    usage.Version,
+				License:    usage.License,
+				Reason:     reason,
+			})
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Repository != violations[j].Repository {
+			return violations[i].Repository < violations[j].Repository
+		}
+		return violations[i].Package < violations[j].Package
+	})
+
+	// The gate can't pass on repositories it couldn't read
+	var incomplete error
+	switch {
+	case failed > 0:
+		incomplete = fmt.Errorf("unable to read the dependency graph of %d of %d repositories", failed, len(sboms))
+	case skipped == len(sboms):
+		incomplete = errors.New("no dependency graph found: enable the dependency graph to check the licenses")
+	}
+
+	if jsonOutput {
+		if err := jsonLister(violations); err != nil {
+			return len(violations), err
+		}
+		return len(violations), incomplete
+	}
+
+	if len(violations) > 0 {
+		tp, err := getTablePrinter()
+		if err != nil {
+			return 0, err
+		}
+		tp.AddHeader([]string{"Repository", "Ecosystem", "Package", "Version", "License", "Reason"})
+		for _, v := range violations {
+			license := v.License
+			if license == "" {
+				license = "-"
+			}
+			tp.AddField(v.Repository)
+			tp.AddField(v.Ecosystem)
+			tp.AddField(v.Package)
+			tp.AddField(v.Version)
+			tp.AddField(license)
+			tp.AddField(v.Reason)
+			tp.EndRow()
+		}
+		if err := tp.Render(); err != nil {
+			return 0, err
+		}
+	}
+
+	fmt.Printf("%d license violations (%d repositories scanned, %d without dependency graph, %d failed)\n", len(violations), len(sboms), skipped, failed)
+	return len(violations), incomplete
+}
+
+// evaluateLicense checks an SPDX license expression against the policy and returns the
+// reason it is rejected, or "" when compliant. "A OR B" passes when either license passes,
+// "A AND B" only when both do. An expression that can't be parsed is unknown.
+func evaluateLicense(expression string, policy *model.LicensePolicy) string {
+	if strings.TrimSpace(expression) == "" {
+		if policy.FailOnUnknown {
+			return LicenseUnknown
+		}
+		return ""
+	}
+
+	node, err := parseLicenseExpression(expression)
+	if err != nil {
+		return LicenseUnknown
+	}
+	return node.evaluate(policy)
+}
+
+// licenseNode is a parsed SPDX license expression: a license (with its exception, if any)
+// or an AND / OR of expressions
+type licenseNode struct {
+	operator string // "AND", "OR" or "" for a license
+	license  string
+	operands []*licenseNode
+}
+
+func (n *licenseNode) evaluate(policy *model.LicensePolicy) string {
+	if n.operator == "" {
+		return evaluateLicenseID(n.license, policy)
+	}
+
+	reason := ""
+	for _, operand := range n.operands {
+		r := operand.evaluate(policy)
+		switch {
+		case r == "" && n.operator == "OR":
+			return ""
+		case r == "" || reason == LicenseDenied:
+		default:
+			reason = r
+		}
+	}
+	return reason
+}
+
+// parseLicenseExpression parses an SPDX license expression. AND binds tighter than OR,
+// parentheses group and operators are case-insensitive:
+//
+//	or      = and { "OR" and }
+//	and     = with { "AND" with }
+//	with    = primary [ "WITH" exception ]
+//	primary = license | "(" or ")"
+func parseLicenseExpression(expression string) (*licenseNode, error) {
+	p := &licenseParser{tokens: strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression))}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("invalid license expression '%s': unexpected '%s'", expression, p.peek())
+	}
+	return node, nil
+}
+
+type licenseParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *licenseParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *licenseParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// accept consumes the next token when it is the given operator
+func (p *licenseParser) accept(operator string) bool {
+	if strings.EqualFold(p.peek(), operator) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *licenseParser) parseOr() (*licenseNode, error) {
+	return p.parseList("OR", p.parseAnd)
+}
+
+func (p *licenseParser) parseAnd() (*licenseNode, error) {
+	return p.parseList("AND", p.parseWith)
+}
+
+// parseList parses operands separated by the operator
+func (p *licenseParser) parseList(operator string, operand func() (*licenseNode, error)) (*licenseNode, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	node := &licenseNode{operator: operator, operands: []*licenseNode{first}}
+	for p.accept(operator) {
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		node.operands = append(node.operands, next)
+	}
+	if len(node.operands) == 1 {
+		return first, nil
+	}
+	return node, nil
+}
+
+func (p *licenseParser) parseWith() (*licenseNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("WITH") {
+		return node, nil
+	}
+	exception := p.peek()
+	if node.operator != "" || !isLicenseToken(exception) {
+		return nil, errors.New("invalid license expression: WITH must join a license and an exception")
+	}
+	p.pos++
+	node.license += " WITH " + exception
+	return node, nil
+}
+
+func (p *licenseParser) parsePrimary() (*licenseNode, error) {
+	if p.accept("(") {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, errors.New("invalid license expression: missing ')'")
+		}
+		return node, nil
+	}
+
+	token := p.peek()
+	if !isLicenseToken(token) {
+		return nil, fmt.Errorf("invalid license expression: expected a license, got '%s'", token)
+	}
+	p.pos++
+	return &licenseNode{license: token}, nil
+}
+
+// isLicenseToken tells whether a token is a license (or exception) identifier rather than an operator or a parenthesis
+func isLicenseToken(token string) bool {
+	switch strings.ToUpper(token) {
+	case "", "(", ")", "AND", "OR", "WITH":
+		return false
+	}
+	return true
+}
+
+func evaluateLicenseID(license string, policy *model.LicensePolicy) string {
+	// "GPL-2.0-only WITH Classpath-exception-2.0" is judged on the full expression first, then on the base license
+	base, _, _ := strings.Cut(license, " WITH ")
+	candidates := []string{license, strings.TrimSpace(base)}
+
+	if matchesLicense(candidates, policy.Deny) {
+		return LicenseDenied
+	}
+	if len(policy.Allow) > 0 && !matchesLicense(candidates, policy.Allow) {
+		return LicenseNotAllowed
+	}
+	return ""
+}
+
+func matchesLicense(candidates, patterns []string) bool {
+	for _, p := range patterns {
+		for _, c := range candidates {
+			if ok, _ := path.Match(strings.ToLower(p), strings.ToLower(c)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
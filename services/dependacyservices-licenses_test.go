@@ -0,0 +1,60 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+func TestEvaluateLicense(t *testing.T) {
+	policy := &model.LicensePolicy{
+		Allow: []string{"MIT", "Apache-2.0", "BSD-*", "GPL-2.0-only WITH Classpath-exception-2.0"},
+		Deny:  []string{"GPL-3.0*", "AGPL-*"},
+	}
+	strict := &model.LicensePolicy{Deny: []string{"GPL-3.0*"}, FailOnUnknown: true}
+
+	tests := []struct {
+		expression string
+		policy     *model.LicensePolicy
+		want       string
+	}{
+		{"MIT", policy, ""},
+		{"mit", policy, ""},
+		{"BSD-3-Clause", policy, ""},
+		{"ISC", policy, LicenseNotAllowed},
+		{"GPL-3.0-only", policy, LicenseDenied},
+		{"", policy, ""},
+		{"", strict, LicenseUnknown},
+		{"MIT OR GPL-3.0-only", policy, ""},
+		{"MIT AND GPL-3.0-only", policy, LicenseDenied},
+		{"MIT and ISC", policy, LicenseNotAllowed},
+		{"ISC AND GPL-3.0-only", policy, LicenseDenied},
+		{"ISC OR GPL-3.0-only", policy, LicenseDenied},
+		// AND binds tighter than OR
+		{"GPL-3.0-only AND MIT OR Apache-2.0", policy, ""},
+		{"MIT OR Apache-2.0 AND GPL-3.0-only", policy, ""},
+		// Parentheses group
+		{"GPL-3.0-only AND (MIT OR Apache-2.0)", policy, LicenseDenied},
+		{"(MIT OR Apache-2.0) AND GPL-3.0-only", policy, LicenseDenied},
+		{"(MIT OR ISC) AND (Apache-2.0 OR GPL-3.0-only)", policy, ""},
+		{"(ISC OR GPL-3.0-only) AND MIT", policy, LicenseDenied},
+		{"((MIT AND (ISC OR BSD-2-Clause)) OR AGPL-3.0-only)", policy, ""},
+		{"((MIT AND (ISC OR 0BSD)) OR AGPL-3.0-only)", policy, LicenseDenied},
+		{"(MIT)", policy, ""},
+		// Exceptions
+		{"GPL-2.0-only WITH Classpath-exception-2.0", policy, ""},
+		{"GPL-2.0-only WITH LLVM-exception", policy, LicenseNotAllowed},
+		{"GPL-3.0-only WITH GCC-exception-3.1 OR MIT", policy, ""},
+		{"(GPL-3.0-only WITH GCC-exception-3.1) AND MIT", policy, LicenseDenied},
+		// Malformed expressions are unknown
+		{"(MIT OR Apache-2.0", policy, LicenseUnknown},
+		{"MIT OR", policy, LicenseUnknown},
+		{"MIT Apache-2.0", policy, LicenseUnknown},
+		{"(MIT OR ISC) WITH Classpath-exception-2.0", policy, LicenseUnknown},
+	}
+	for _, tt := range tests {
+		if got := evaluateLicense(tt.expression, tt.policy); got != tt.want {
+			t.Errorf("evaluateLicense(%q) = %q, want %q", tt.expression, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// Update levels of a Dependabot pull request, from the smallest to the largest change
+var DependabotUpdateLevels = []string{"patch", "minor", "major"}
+
+var bumpTitle = regexp.MustCompile(`(?i)bump (\S+) from (\S+) to (\S+)`)
+
+// DependabotMergePolicy selects the pull requests that can be merged without review:
+// not a draft, checks passing, no conflicts and an update no larger than MaxLevel
+type DependabotMergePolicy struct {
+	MaxLevel    string
+	MergeMethod string
+}
+
+// Validate checks the policy values before anything is merged
+func (p DependabotMergePolicy) Validate() error {
+	if updateLevelRank(p.MaxLevel) < 0 {
+		return fmt.Errorf("invalid max level '%s' (expected %s)", p.MaxLevel, strings.Join(DependabotUpdateLevels, ", "))
+	}
+	if p.MergeMethod != "merge" && p.MergeMethod != "squash" && p.MergeMethod != "rebase" {
+		return fmt.Errorf("invalid merge method '%s' (expected merge, squash or rebase)", p.MergeMethod)
+	}
+	return nil
+}
+
+// Eligible reports whether the pull request matches the policy
+func (p DependabotMergePolicy) Eligible(pr model.DependabotPullRequest) bool {
+	level := updateLevelRank(pr.UpdateLevel)
+	return !pr.Draft && pr.Checks == "SUCCESS" && pr.Mergeable == "MERGEABLE" &&
+		level >= 0 && level <= updateLevelRank(p.MaxLevel)
+}
+
+// ListDependabotPullRequests finds the open Dependabot pull requests of a repository or organization
+// and links them to the alerts they fix. Version update pull requests (without alerts) are only kept
+// with includeVersionUpdates.
+// Docs: GraphQL search + repository.vulnerabilityAlerts.dependabotUpdate
+func (d *DependencyServices) ListDependabotPullRequests(target string, includeVersionUpdates bool) ([]model.DependabotPullRequest, error) {
+	const searchQuery = `query($q: String!, $after: String) {
+  search(query: $q, type: ISSUE, first: 50, after: $after) {
+    pageInfo { hasNextPage endCursor }
+    nodes {
+      ... on PullRequest {
+        number title url createdAt headRefName mergeable isDraft
+        repository { nameWithOwner }
+        commits(last: 1) { nodes { commit { oid statusCheckRollup { state } } } }
+      }
+    }
+  }
+}`
+
+	scope := "org:" + target
+	if strings.Contains(target, "/") {
+		scope = "repo:" + target
+	}
+	variables := map[string]interface{}{"q": scope + " is:pr is:open author:app/dependabot", "after": nil}
+
+	var nodes []model.PullRequestNode
+	for {
+		var response struct {
+			Search model.PullRequestSearch `json:"search"`
+		}
+		if err := graphQL(searchQuery, variables, &response); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, response.Search.Nodes...)
+
+		if !response.Search.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = response.Search.PageInfo.EndCursor
+	}
+
+	linked := map[string]map[int][]model.DependabotLinkedAlert{}
+	prs := []model.DependabotPullRequest{}
+	for _, node := range nodes {
+		repository := node.Repository.NameWithOwner
+		if _, done := linked[repository]; !done {
+			owner, repo, _ := strings.Cut(repository, "/")
+			alerts, err := d.linkedDependabotAlerts(owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			linked[repository] = alerts
+		}
+
+		pr := newDependabotPullRequest(node)
+		pr.Alerts = linked[repository][node.Number]
+		if pr.Alerts == nil {
+			if !includeVersionUpdates {
+				continue
+			}
+			pr.Alerts = []model.DependabotLinkedAlert{}
+		}
+		prs = append(prs, pr)
+	}
+
+	sort.Slice(prs, func(i, j int) bool { return prs[i].AgeDays > prs[j].AgeDays })
+	return prs, nil
+}
+
+// linkedDependabotAlerts maps the pull request numbers of a repository to the open alerts they fix
+func (d *DependencyServices) linkedDependabotAlerts(owner, repo string) (map[int][]model.DependabotLinkedAlert, error) {
+	const alertsQuery = `query($owner: String!, $name: String!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    vulnerabilityAlerts(first: 100, states: [OPEN], after: $after) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number
+        securityAdvisory { ghsaId }
+        securityVulnerability { severity }
+        dependabotUpdate { pullRequest { number } }
+      }
+    }
+  }
+}`
+
+	linked := map[int][]model.DependabotLinkedAlert{}
+	variables := map[string]interface{}{"owner": owner, "name": repo, "after": nil}
+	for {
+		var response struct {
+			Repository struct {
+				VulnerabilityAlerts model.VulnerabilityAlerts `json:"vulnerabilityAlerts"`
+			} `json:"repository"`
+		}
+		if err := graphQL(alertsQuery, variables, &response); err != nil {
+			return nil, err
+		}
+
+		connection := response.Repository.VulnerabilityAlerts
+		for _, alert := range connection.Nodes {
+			if alert.DependabotUpdate == nil || alert.DependabotUpdate.PullRequest == nil {
+				continue
+			}
+			number := alert.DependabotUpdate.PullRequest.Number
+			linked[number] = append(linked[number], model.DependabotLinkedAlert{
+				Number:   alert.Number,
+				GHSAId:   alert.SecurityAdvisory.GHSAId,
+				Severity: strings.ToLower(alert.SecurityVulnerability.Severity),
+			})
+		}
+
+		if !connection.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = connection.PageInfo.EndCursor
+	}
+	return linked, nil
+}
+
+func newDependabotPullRequest(node model.PullRequestNode) model.DependabotPullRequest {
+	pr := model.DependabotPullRequest{
+		Repository:  node.Repository.NameWithOwner,
+		Number:      node.Number,
+		Title:       node.Title,
+		URL:         node.URL,
+		Branch:      node.HeadRefName,
+		CreatedAt:   node.CreatedAt,
+		Mergeable:   node.Mergeable,
+		Draft:       node.IsDraft,
+		Checks:      "NONE",
+		UpdateLevel: "unknown",
+	}
+
+	if created, err := time.Parse(time.RFC3339, node.CreatedAt); err == nil {
+		pr.AgeDays = int(time.Since(created).Hours() / 24)
+	}
+	if len(node.Commits.Nodes) > 0 {
+		commit := node.Commits.Nodes[0].Commit
+		pr.HeadSha = commit.Oid
+		if commit.StatusCheckRollup != nil {
+			pr.Checks = commit.StatusCheckRollup.State
+		}
+	}
+
+	// Grouped updates bump several packages: never eligible for automatic merge
+	if strings.Contains(strings.ToLower(node.Title), " group ") {
+		pr.UpdateLevel = "group"
+	} else if match := bumpTitle.FindStringSubmatch(node.Title); match != nil {
+		pr.Package = match[1]
+		pr.FromVersion = match[2]
+		pr.ToVersion = match[3]
+		pr.UpdateLevel = updateLevel(pr.FromVersion, pr.ToVersion)
+	}
+	return pr
+}
+
+// updateLevel classifies a version change as major, minor or patch.
+// Before 1.0 the first non-zero segment is the breaking one (semver: "anything may change"),
+// so 0.3 -> 0.4 and 0.0.3 -> 0.0.4 are major.
+func updateLevel(from, to string) string {
+	partsFrom := strings.Split(strings.TrimPrefix(from, "v"), ".")
+	partsTo := strings.Split(strings.TrimPrefix(to, "v"), ".")
+	zero := true
+	for i, level := range []string{"major", "minor", "patch"} {
+		segFrom, segTo := "0", "0"
+		if i < len(partsFrom) {
+			segFrom = partsFrom[i]
+		}
+		if i < len(partsTo) {
+			segTo = partsTo[i]
+		}
+		if compareSegment(segFrom, segTo) != 0 {
+			if zero {
+				return "major"
+			}
+			return level
+		}
+		zero = zero && segFrom == "0"
+	}
+	return "patch"
+}
+
+func updateLevelRank(level string) int {
+	for i, l := range DependabotUpdateLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// PrintDependabotPullRequests renders the pull requests with their age, checks and linked alerts
+func (d *DependencyServices) PrintDependabotPullRequests(prs []model.DependabotPullRequest, jsonOutput bool) error {
+	if jsonOutput {
+		return jsonLister(prs)
+	}
+
+	tp, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	tp.AddHeader([]string{"Repository", "PR", "Package", "Update", "Level", "Age", "Checks", "Mergeable", "Alerts"})
+	for _, pr := range prs {
+		update := "-"
+		if pr.FromVersion != "" {
+			update = pr.FromVersion + " -> " + pr.ToVersion
+		}
+		pkg := pr.Package
+		if pkg == "" {
+			pkg = pr.Title
+		}
+		alerts := []string{}
+		for _, a := range pr.Alerts {
+			alerts = append(alerts, fmt.Sprintf("#%d %s (%s)", a.Number, a.GHSAId, a.Severity))
+		}
+		mergeable := pr.Mergeable
+		if pr.Draft {
+			mergeable = "DRAFT"
+		}
+
+		tp.AddField(pr.Repository)
+		tp.AddField(fmt.Sprintf("#%d", pr.Number))
+		tp.AddField(pkg)
+		tp.AddField(update)
+		tp.AddField(pr.UpdateLevel)
+		tp.AddField(fmt.Sprintf("%dd", pr.AgeDays))
+		tp.AddField(pr.Checks)
+		tp.AddField(mergeable)
+		tp.AddField(strings.Join(alerts, ", "))
+		tp.EndRow()
+	}
+	return tp.Render()
+}
+
+// MergeDependabotPullRequests merges the pull requests one at a time, as merging several
+// pull requests of the same repository in parallel would make the others conflict.
+// Returns the number of pull requests merged; failures are reported and skipped.
+// Docs: PUT /repos/{owner}/{repo}/pulls/{pull_number}/merge
+func (d *DependencyServices) MergeDependabotPullRequests(prs []model.DependabotPullRequest, mergeMethod string) int {
+	merged := 0
+	for _, pr := range prs {
+		path := fmt.Sprintf("repos/%s/pulls/%d/merge", pr.Repository, pr.Number)
+		// The sha makes GitHub refuse the merge when commits were pushed after the checks were evaluated
+		if err := put(path, model.MergePullRequest{MergeMethod: mergeMethod, Sha: pr.HeadSha}, nil); err != nil {
+			fmt.Printf("- %s #%d: %s\n", pr.Repository, pr.Number, err)
+			continue
+		}
+		merged++
+	}
+	return merged
+}
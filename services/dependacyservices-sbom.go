@@ -0,0 +1,233 @@
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// FetchSBOM retrieves the SPDX SBOM of a repository, both raw (to export it untouched) and parsed
+// Docs: GET /repos/{owner}/{repo}/dependency-graph/sbom
+func (d *DependencyServices) FetchSBOM(org, repo string) (json.RawMessage, *model.SPDXDocument, error) {
+	path := fmt.Sprintf("repos/%s/%s/dependency-graph/sbom", org, repo)
+
+	// getPages handles the Request/Unmarshal logic nicely, even though there's no pagination for SBOMs.
+	response := model.SBOMResponse{}
+	if _, err := getPages(path, &response); err != nil {
+		return nil, nil, err
+	}
+
+	doc := &model.SPDXDocument{}
+	if err := json.Unmarshal(response.SBOM, doc); err != nil {
+		return nil, nil, err
+	}
+	return response.SBOM, doc, nil
+}
+
+// ExportSBOM fetches the SBOM of a repository in the given format (spdx-json or cyclonedx-json)
+// and writes it to output, or to the terminal when output is empty.
+func (d *DependencyServices) ExportSBOM(org, repo, format, output string) error {
+	if err := validateSBOMFormat(format); err != nil {
+		return err
+	}
+
+	raw, doc, err := d.FetchSBOM(org, repo)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		// Always output SBOM as JSON (it's a data format)
+		if format == SBOMFormatCycloneDX {
+			bom, err := ConvertSPDXToCycloneDX(*doc)
+			if err != nil {
+				return err
+			}
+			return jsonLister(bom)
+		}
+		return jsonLister(raw)
+	}
+
+	data, err := renderSBOM(raw, doc, format)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("SBOM (%s, %d packages) saved to %s\n", format, len(doc.Packages), output)
+	return nil
+}
+
+// BulkExportSBOM writes one SBOM per repository of the organization plus a manifest.json.
+// When output ends with .tar.gz or .tgz a gzipped tarball is written, otherwise a directory.
+// The archive is written even when some repositories fail: their errors are in the manifest and counted in the returned error.
+func (d *DependencyServices) BulkExportSBOM(org, format, output string) error {
+	if err := validateSBOMFormat(format); err != nil {
+		return err
+	}
+
+	repos, err := GetRepositoryServices().FetchAllForOrg(org)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exporting SBOMs for %d repositories. This may take a while...\n", len(repos))
+
+	extension := ".spdx.json"
+	if format == SBOMFormatCycloneDX {
+		extension = ".cdx.json"
+	}
+
+	files := map[string][]byte{}
+	manifest := model.SBOMManifest{
+		Organization: org,
+		Format:       format,
+		GeneratedAt:  time.Now().UTC().Format(time.RFC3339),
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, 5)
+
+	for _, repo := range repos {
+		wg.Add(1)
+		go func(repoName string) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			entry := model.SBOMManifestEntry{Repository: org + "/" + repoName, Format: format}
+
+			raw, doc, err := d.FetchSBOM(org, repoName)
+			var data []byte
+			if err == nil {
+				data, err = renderSBOM(raw, doc, format)
+			}
+
+			if err != nil {
+				entry.Error = err.Error()
+			} else {
+				sum := sha256.Sum256(data)
+				entry.File = repoName + extension
+				entry.Packages = len(doc.Packages)
+				entry.SHA256 = hex.EncodeToString(sum[:])
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if entry.Error == "" {
+				files[entry.File] = data
+			}
+			manifest.Entries = append(manifest.Entries, entry)
+		}(repo.Name)
+	}
+	wg.Wait()
+
+	sort.Slice(manifest.Entries, func(i, j int) bool {
+		return manifest.Entries[i].Repository < manifest.Entries[j].Repository
+	})
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	files["manifest.json"] = manifestData
+
+	if strings.HasSuffix(output, ".tar.gz") || strings.HasSuffix(output, ".tgz") {
+		err = writeTarball(output, files)
+	} else {
+		err = writeDirectory(output, files)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Done! %d of %d SBOMs saved to %s\n", len(files)-1, len(repos), output)
+	if failed := len(repos) - (len(files) - 1); failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed (see manifest.json)", failed, len(repos))
+	}
+	return nil
+}
+
+func validateSBOMFormat(format string) error {
+	if format != SBOMFormatSPDX && format != SBOMFormatCycloneDX {
+		return fmt.Errorf("unsupported SBOM format '%s' (expected %s or %s)", format, SBOMFormatSPDX, SBOMFormatCycloneDX)
+	}
+	return nil
+}
+
+// renderSBOM serializes the SBOM in the requested format
+func renderSBOM(raw json.RawMessage, doc *model.SPDXDocument, format string) ([]byte, error) {
+	switch format {
+	case SBOMFormatSPDX:
+		var out bytes.Buffer
+		if err := json.Indent(&out, raw, "", "  "); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	case SBOMFormatCycloneDX:
+		bom, err := ConvertSPDXToCycloneDX(*doc)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(bom, "", "  ")
+	}
+	return nil, validateSBOMFormat(format)
+}
+
+func writeDirectory(dir string, files map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTarball(output string, files map[string][]byte) error {
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	for _, name := range names {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), ModTime: now}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// GetDependabotAlert fetches a single Dependabot alert
+func (d *DependencyServices) GetDependabotAlert(owner, repo string, number int) (*model.DependabotAlert, error) {
+	alert := &model.DependabotAlert{}
+	path := fmt.Sprintf("repos/%s/%s/dependabot/alerts/%d", owner, repo, number)
+	err := client.Get(path, alert)
+	return alert, err
+}
+
+// ShowDependabotAlert renders a Dependabot alert with its advisory scores (CVSS, EPSS), CWEs and patched version
+func (d *DependencyServices) ShowDependabotAlert(owner, repo string, number int, jsonOutput bool) error {
+	alert, err := d.GetDependabotAlert(owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return jsonLister(alert)
+	}
+
+	tablePrinter, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	advisory := alert.SecurityAdvisory
+	vulnerability := alert.SecurityVulnerability
+
+	ids := []string{advisory.GHSAId}
+	if advisory.CVEId != "" {
+		ids = append(ids, advisory.CVEId)
+	}
+
+	tablePrinter.AddField("Alert")
+	tablePrinter.AddField(fmt.Sprintf("#%d", alert.Number))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Advisory")
+	tablePrinter.AddField(strings.Join(ids, " / "))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Summary")
+	tablePrinter.AddField(advisory.Summary)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("State")
+	tablePrinter.AddField(alert.State)
+	tablePrinter.EndRow()
+	if alert.State == "dismissed" {
+		tablePrinter.AddField("Dismissed")
+		tablePrinter.AddField(fmt.Sprintf("%s by %s at %s", alert.DismissedReason, alert.DismissedBy.Login, alert.DismissedAt))
+		tablePrinter.EndRow()
+	}
+	tablePrinter.AddField("Severity")
+	tablePrinter.AddField(advisory.Severity)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("CVSS")
+	tablePrinter.AddField(strings.TrimSpace(formatCVSS(advisory) + " " + advisoryCVSSVector(advisory)))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("EPSS")
+	tablePrinter.AddField(formatEPSSDetails(advisory))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("CWEs")
+	tablePrinter.AddField(formatCWEs(advisory, true))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Package")
+	tablePrinter.AddField(fmt.Sprintf("%s (%s)", alert.Dependency.Package.Name, alert.Dependency.Package.Ecosystem))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Manifest")
+	tablePrinter.AddField(fmt.Sprintf("%s (%s)", alert.Dependency.ManifestPath, alert.Dependency.Scope))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Vulnerable Versions")
+	tablePrinter.AddField(vulnerability.VulnerableVersionRange)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("First Patched Version")
+	tablePrinter.AddField(formatPatchedVersion(vulnerability))
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Published At")
+	tablePrinter.AddField(advisory.PublishedAt)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("Created At")
+	tablePrinter.AddField(alert.CreatedAt)
+	tablePrinter.EndRow()
+	tablePrinter.AddField("URL")
+	tablePrinter.AddField(alert.HtmlUrl)
+	tablePrinter.EndRow()
+	for _, ref := range advisory.References {
+		tablePrinter.AddField("Reference")
+		tablePrinter.AddField(ref.URL)
+		tablePrinter.EndRow()
+	}
+
+	return tablePrinter.Render()
+}
+
+// advisoryCVSS returns the CVSS base score, preferring v3 (the one shown by GitHub) over v4
+func advisoryCVSS(advisory model.SecurityAdvisory) float64 {
+	switch {
+	case advisory.CVSSSeverities.CVSSV3.Score > 0:
+		return advisory.CVSSSeverities.CVSSV3.Score
+	case advisory.CVSS.Score > 0:
+		return advisory.CVSS.Score
+	}
+	return advisory.CVSSSeverities.CVSSV4.Score
+}
+
+func advisoryCVSSVector(advisory model.SecurityAdvisory) string {
+	switch {
+	case advisory.CVSSSeverities.CVSSV3.VectorString != "":
+		return advisory.CVSSSeverities.CVSSV3.VectorString
+	case advisory.CVSS.VectorString != "":
+		return advisory.CVSS.VectorString
+	}
+	return advisory.CVSSSeverities.CVSSV4.VectorString
+}
+
+// advisoryEPSS returns the EPSS percentile and whether the advisory has EPSS data
+func advisoryEPSS(advisory model.SecurityAdvisory) (float64, bool) {
+	if len(advisory.EPSS) == 0 {
+		return 0, false
+	}
+	return advisory.EPSS[0].Percentile, true
+}
+
+func formatCVSS(advisory model.SecurityAdvisory) string {
+	score := advisoryCVSS(advisory)
+	if score == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", score)
+}
+
+func formatEPSS(advisory model.SecurityAdvisory) string {
+	percentile, ok := advisoryEPSS(advisory)
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", percentile*100)
+}
+
+func formatEPSSDetails(advisory model.SecurityAdvisory) string {
+	if len(advisory.EPSS) == 0 {
+		return "-"
+	}
+	epss := advisory.EPSS[0]
+	return fmt.Sprintf("%.2f%% probability (percentile %.0f%%)", epss.Percentage*100, epss.Percentile*100)
+}
+
+// formatCWEs joins the CWE IDs, optionally with their names
+func formatCWEs(advisory model.SecurityAdvisory, withNames bool) string {
+	if len(advisory.CWEs) == 0 {
+		return "-"
+	}
+	cwes := []string{}
+	for _, c := range advisory.CWEs {
+		if withNames {
+			cwes = append(cwes, fmt.Sprintf("%s %s", c.CWEId, c.Name))
+		} else {
+			cwes = append(cwes, c.CWEId)
+		}
+	}
+	if withNames {
+		return strings.Join(cwes, "; ")
+	}
+	return strings.Join(cwes, ",")
+}
+
+func formatPatchedVersion(vulnerability model.SecurityVulnerability) string {
+	if vulnerability.FirstPatchedVersion.Identifier == "" {
+		return "none"
+	}
+	return vulnerability.FirstPatchedVersion.Identifier
+}
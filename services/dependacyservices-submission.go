@@ -0,0 +1,277 @@
+package services
+
+import (
+	"bufio"
+	"debug/buildinfo"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+var (
+	commitSha       = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	requirementLine = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*(.*)$`)
+
+	pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+)
+
+// NewSnapshot returns an empty snapshot for a commit, with this tool as detector
+func (d *DependencyServices) NewSnapshot(sha, ref, correlator string) *model.DependencySnapshot {
+	return &model.DependencySnapshot{
+		Version: 0,
+		Sha:     sha,
+		Ref:     ref,
+		Job: model.SnapshotJob{
+			Correlator: correlator,
+			ID:         fmt.Sprintf("%d", time.Now().Unix()),
+		},
+		Detector: model.SnapshotDetector{
+			Name:    "gh-advanced-security",
+			Version: detectorVersion(),
+			URL:     "https://github.com/messagedigest-net/gh-advanced-security",
+		},
+		Scanned:   time.Now().UTC().Format(time.RFC3339),
+		Manifests: map[string]*model.SnapshotManifest{},
+	}
+}
+
+// LoadSnapshot reads a snapshot prepared by another tool
+func (d *DependencyServices) LoadSnapshot(file string) (*model.DependencySnapshot, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &model.DependencySnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", file, err)
+	}
+	return snapshot, nil
+}
+
+// SubmitSnapshot validates and sends a dependency snapshot, so Dependabot can alert on
+// dependencies GitHub does not detect from the repository content
+// Docs: POST /repos/{owner}/{repo}/dependency-graph/snapshots
+func (d *DependencyServices) SubmitSnapshot(owner, repo string, snapshot *model.DependencySnapshot) (*model.SnapshotResult, error) {
+	if !commitSha.MatchString(snapshot.Sha) {
+		return nil, fmt.Errorf("invalid commit sha '%s' (expected the full 40 character sha)", snapshot.Sha)
+	}
+	if !strings.HasPrefix(snapshot.Ref, "refs/") {
+		return nil, fmt.Errorf("invalid ref '%s' (expected e.g. refs/heads/main)", snapshot.Ref)
+	}
+	if len(snapshot.Manifests) == 0 {
+		return nil, fmt.Errorf("the snapshot has no manifests")
+	}
+
+	result := &model.SnapshotResult{}
+	path := fmt.Sprintf("repos/%s/%s/dependency-graph/snapshots", owner, repo)
+	if err := post(path, snapshot, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PreviewSnapshot prints the snapshot that would be submitted
+func (d *DependencyServices) PreviewSnapshot(snapshot *model.DependencySnapshot) error {
+	return jsonLister(snapshot)
+}
+
+// AddGoBinaryManifest adds the modules embedded in a Go binary's build info
+func (d *DependencyServices) AddGoBinaryManifest(snapshot *model.DependencySnapshot, binary string) error {
+	info, err := buildinfo.ReadFile(binary)
+	if err != nil {
+		return fmt.Errorf("unable to read Go build info from %s: %w", binary, err)
+	}
+
+	// Keyed by path: binaries of the same name in different directories are different manifests
+	manifest := &model.SnapshotManifest{
+		Name:     manifestPath(binary),
+		File:     &model.SnapshotManifestFile{SourceLocation: manifestPath(binary)},
+		Metadata: map[string]interface{}{"go_version": info.GoVersion, "main_module": info.Main.Path},
+		Resolved: map[string]*model.SnapshotDependency{},
+	}
+
+	for _, dep := range info.Deps {
+		module := dep
+		// A replacement by a local directory has no version: the original module stands for it
+		if dep.Replace != nil && dep.Replace.Version != "" {
+			module = dep.Replace
+		}
+		if module.Version == "" {
+			continue
+		}
+		// Build info doesn't say which modules are direct requirements
+		manifest.Resolved[module.Path] = &model.SnapshotDependency{
+			PackageURL: fmt.Sprintf("pkg:golang/%s@%s", module.Path, module.Version),
+			Scope:      "runtime",
+		}
+	}
+
+	snapshot.Manifests[manifest.Name] = manifest
+	return nil
+}
+
+// AddRequirementsManifest adds the packages of a pip requirements file that has no lockfile.
+// Pinned requirements (name==version) are submitted with their version, the others without one.
+// Continued lines and per-requirement options (e.g. the --hash of pip-compile) are supported.
+func (d *DependencyServices) AddRequirementsManifest(snapshot *model.DependencySnapshot, file string) error {
+	lines, err := readListFile(file)
+	if err != nil {
+		return err
+	}
+
+	manifest := &model.SnapshotManifest{
+		Name:     manifestPath(file),
+		File:     &model.SnapshotManifestFile{SourceLocation: manifestPath(file)},
+		Resolved: map[string]*model.SnapshotDependency{},
+	}
+
+	for _, line := range joinContinuedLines(lines) {
+		// Options (-r, -e, --index-url...) and direct URLs can't be resolved to a package
+		if strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+		line, _, _ = strings.Cut(line, ";") // environment markers
+		line = withoutRequirementOptions(line)
+
+		match := requirementLine.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			return fmt.Errorf("%s: unable to parse requirement '%s'", file, line)
+		}
+
+		// PEP 503 normalized name
+		name := strings.ToLower(pypiNameSeparators.ReplaceAllString(match[1], "-"))
+		purl := "pkg:pypi/" + name
+		if version, found := strings.CutPrefix(strings.TrimSpace(match[3]), "=="); found && !strings.ContainsAny(version, ",*") {
+			purl += "@" + strings.TrimSpace(version)
+		}
+
+		manifest.Resolved[name] = &model.SnapshotDependency{
+			PackageURL:   purl,
+			Relationship: "direct",
+			Scope:        "runtime",
+		}
+	}
+
+	snapshot.Manifests[manifest.Name] = manifest
+	return nil
+}
+
+// joinContinuedLines joins the lines ending with a backslash with the next one
+func joinContinuedLines(lines []string) []string {
+	joined := []string{}
+	continued := false
+	for _, line := range lines {
+		if continued {
+			joined[len(joined)-1] += " " + line
+		} else {
+			joined = append(joined, line)
+		}
+		last := &joined[len(joined)-1]
+		*last, continued = strings.CutSuffix(*last, "\\")
+		*last = strings.TrimSpace(*last)
+	}
+	return joined
+}
+
+// withoutRequirementOptions drops the options following a requirement (e.g. --hash=sha256:...)
+func withoutRequirementOptions(line string) string {
+	fields := strings.Fields(line)
+	for i, field := range fields {
+		if strings.HasPrefix(field, "-") {
+			fields = fields[:i]
+			break
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// AddPurlManifest adds a plain list of package URLs (one per line), for build systems
+// that can print their resolved dependencies but have no format GitHub understands
+func (d *DependencyServices) AddPurlManifest(snapshot *model.DependencySnapshot, file string) error {
+	lines, err := readListFile(file)
+	if err != nil {
+		return err
+	}
+
+	manifest := &model.SnapshotManifest{
+		Name:     manifestPath(file),
+		File:     &model.SnapshotManifestFile{SourceLocation: manifestPath(file)},
+		Resolved: map[string]*model.SnapshotDependency{},
+	}
+
+	for _, purl := range lines {
+		kind, namespace, name, _ := parsePurl(purl)
+		if !strings.HasPrefix(purl, "pkg:") || kind == "" || name == "" {
+			return fmt.Errorf("%s: invalid package URL '%s'", file, purl)
+		}
+		key := name
+		if namespace != "" {
+			key = namespace + "/" + name
+		}
+		manifest.Resolved[key] = &model.SnapshotDependency{PackageURL: purl, Scope: "runtime"}
+	}
+
+	snapshot.Manifests[manifest.Name] = manifest
+	return nil
+}
+
+// manifestPath returns the path of a manifest relative to the working directory (the repository
+// checkout, in CI), with forward slashes
+func manifestPath(file string) string {
+	if filepath.IsAbs(file) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+// SnapshotCorrelator returns a correlator derived from the manifest names: a later submission
+// for the same manifests replaces the previous one instead of adding to it
+func SnapshotCorrelator(snapshot *model.DependencySnapshot) string {
+	names := make([]string, 0, len(snapshot.Manifests))
+	for name := range snapshot.Manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "gh-advanced-security:" + strings.Join(names, ",")
+}
+
+// readListFile returns the non empty lines of a file, without # comments
+func readListFile(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func detectorVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "dev"
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+func TestAddRequirementsManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     map[string]string
+	}{
+		{
+			name: "pip-compile",
+			contents: `#
+# This file is autogenerated by pip-compile with Python 3.12
+#
+certifi==2024.2.2 \
+    --hash=sha256:0569859f95fc761b18b45ef421b1290a0f65f147e92a1e5eb3e635f9a5e4e66f \
+    --hash=sha256:dc383c07b76109f368f6106eee2b593b04a011ea4d55f652c6ca24a754d1cdd1
+    # via requests
+flask==2.0 --hash=sha256:4efa1ae2d7c9865af48986de8aeb8504bf32c7f3d6fdc9353d34b21f4b127060
+requests==2.31.0 \
+    --hash=sha256:58cd2187c01e70e6e26505bca751777aa9f2ee0b7f4300988b709f44e013003f
+    # via -r requirements.in
+urllib3==2.2.1 ; python_version >= "3.8" \
+    --hash=sha256:450b20ec296a467077128bff42b73080516e71b56ff59a60a02bef2232c4fa9d
+`,
+			want: map[string]string{
+				"certifi":  "pkg:pypi/certifi@2024.2.2",
+				"flask":    "pkg:pypi/flask@2.0",
+				"requests": "pkg:pypi/requests@2.31.0",
+				"urllib3":  "pkg:pypi/urllib3@2.2.1",
+			},
+		},
+		{
+			name: "hand written",
+			contents: `--index-url https://pypi.org/simple
+-r base.txt
+Django>=4.2,<5  # LTS
+zope.interface==6.0
+requests[security] == 2.31.0
+https://example.com/package.tar.gz
+`,
+			want: map[string]string{
+				"django":         "pkg:pypi/django",
+				"zope-interface": "pkg:pypi/zope-interface@6.0",
+				"requests":       "pkg:pypi/requests@2.31.0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "requirements.txt")
+			if err := os.WriteFile(file, []byte(tt.contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			snapshot := &model.DependencySnapshot{Manifests: map[string]*model.SnapshotManifest{}}
+			if err := GetDependencyServices().AddRequirementsManifest(snapshot, file); err != nil {
+				t.Fatal(err)
+			}
+
+			resolved := snapshot.Manifests[manifestPath(file)].Resolved
+			if len(resolved) != len(tt.want) {
+				t.Errorf("got %d packages, want %d: %v", len(resolved), len(tt.want), resolved)
+			}
+			for name, purl := range tt.want {
+				if dep, ok := resolved[name]; !ok || dep.PackageURL != purl {
+					t.Errorf("%s = %v, want %s", name, dep, purl)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// DependabotDismissReasons are the reasons accepted by the API when dismissing an alert
+var DependabotDismissReasons = []string{"fix_started", "inaccurate", "no_bandwidth", "not_used", "tolerable_risk"}
+
+// DependabotAlertFilter selects the alerts affected by a bulk operation.
+// Advisory matches either the GHSA or the CVE ID, Package the package name and
+// Manifest the manifest path. Empty fields match everything.
+type DependabotAlertFilter struct {
+	Advisory string
+	Package  string
+	Manifest string
+}
+
+// IsEmpty reports whether no criteria was given (which would match every alert)
+func (f DependabotAlertFilter) IsEmpty() bool {
+	return f.Advisory == "" && f.Package == "" && f.Manifest == ""
+}
+
+func (f DependabotAlertFilter) matches(alert model.DependabotAlert) bool {
+	if f.Advisory != "" &&
+		!strings.EqualFold(alert.SecurityAdvisory.GHSAId, f.Advisory) &&
+		!strings.EqualFold(alert.SecurityAdvisory.CVEId, f.Advisory) {
+		return false
+	}
+	if f.Package != "" && !strings.EqualFold(alert.Dependency.Package.Name, f.Package) {
+		return false
+	}
+	if f.Manifest != "" && alert.Dependency.ManifestPath != f.Manifest {
+		return false
+	}
+	return true
+}
+
+// DismissDependabotAlert dismisses a single alert with one of DependabotDismissReasons
+// Docs: PATCH /repos/{owner}/{repo}/dependabot/alerts/{alert_number}
+func (d *DependencyServices) DismissDependabotAlert(owner, repo string, number int, reason, comment string) error {
+	if !slices.Contains(DependabotDismissReasons, reason) {
+		return fmt.Errorf("invalid dismiss reason '%s' (expected one of %s)", reason, strings.Join(DependabotDismissReasons, ", "))
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/dependabot/alerts/%d", owner, repo, number)
+	payload := model.UpdateAlert{
+		State:            "dismissed",
+		DismissedReason:  reason,
+		DismissedComment: comment,
+	}
+	return patch(path, payload)
+}
+
+// ReopenDependabotAlert sets a dismissed alert back to open
+func (d *DependencyServices) ReopenDependabotAlert(owner, repo string, number int) error {
+	path := fmt.Sprintf("repos/%s/%s/dependabot/alerts/%d", owner, repo, number)
+	payload := model.UpdateAlert{
+		State: "open",
+	}
+	return patch(path, payload)
+}
+
+// FindOpenDependabotAlerts retrieves the open alerts matching the filter for a repository,
+// or for the whole organization when repo is empty.
+// Package is filtered by the API, advisory and manifest locally.
+func (d *DependencyServices) FindOpenDependabotAlerts(owner, repo string, filter DependabotAlertFilter) ([]model.DependabotAlert, error) {
+	query := url.Values{}
+	query.Set("state", "open")
+	query.Set("per_page", "100")
+	if filter.Package != "" {
+		query.Set("package", filter.Package)
+	}
+
+	path := fmt.Sprintf("orgs/%s/dependabot/alerts?%s", owner, query.Encode())
+	if repo != "" {
+		if filter.Manifest != "" {
+			query.Set("manifest", filter.Manifest)
+		}
+		path = fmt.Sprintf("repos/%s/%s/dependabot/alerts?%s", owner, repo, query.Encode())
+	}
+
+	var matched []model.DependabotAlert
+	for {
+		var pageAlerts []model.DependabotAlert
+		nextUrl, err := getPages(path, &pageAlerts)
+		if err != nil {
+			return nil, err
+		}
+		for _, alert := range pageAlerts {
+			if repo != "" {
+				// Repository level listings don't carry the repository
+				alert.Repository.FullName = owner + "/" + repo
+			}
+			if filter.matches(alert) {
+				matched = append(matched, alert)
+			}
+		}
+		if nextUrl == "" {
+			break
+		}
+		path = nextUrl
+	}
+	return matched, nil
+}
+
+// BulkDismissDependabotAlerts dismisses every given alert in parallel.
+// Returns the number of alerts dismissed; failures are reported and skipped.
+func (d *DependencyServices) BulkDismissDependabotAlerts(alerts []model.DependabotAlert, reason, comment string) int {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, 5)
+	dismissed := 0
+
+	for _, alert := range alerts {
+		wg.Add(1)
+		go func(a model.DependabotAlert) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			owner, repo, _ := strings.Cut(a.Repository.FullName, "/")
+			err := d.DismissDependabotAlert(owner, repo, a.Number, reason, comment)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				fmt.Printf("- %s #%d: %s\n", a.Repository.FullName, a.Number, err)
+				return
+			}
+			dismissed++
+		}(alert)
+	}
+
+	wg.Wait()
+	return dismissed
+}
+
+// PrintDependabotAlerts renders a list of alerts including their repository (used for bulk previews)
+func (d *DependencyServices) PrintDependabotAlerts(alerts []model.DependabotAlert) error {
+	tp, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	tp.AddHeader([]string{"Repository", "ID", "Severity", "Package", "CVE/GHSA", "Manifest"})
+	for _, alert := range alerts {
+		id := alert.SecurityAdvisory.CVEId
+		if id == "" {
+			id = alert.SecurityAdvisory.GHSAId
+		}
+
+		tp.AddField(alert.Repository.FullName)
+		tp.AddField(fmt.Sprintf("%d", alert.Number))
+		tp.AddField(alert.SecurityAdvisory.Severity)
+		tp.AddField(alert.Dependency.Package.Name)
+		tp.AddField(id)
+		tp.AddField(alert.Dependency.ManifestPath)
+		tp.EndRow()
+	}
+	return tp.Render()
+}
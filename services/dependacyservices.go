@@ -19,11 +19,52 @@ func GetDependencyServices() *DependencyServices {
 	return depSvcs
 }
 
+// DependabotListFilter keeps only alerts at or above the given exploitability scores.
+// Zero values disable the filter.
+type DependabotListFilter struct {
+	MinCVSS           float64
+	MinEPSSPercentile float64
+}
+
+// active tells whether the filter has criteria, matched locally as the API has no score filters
+func (f DependabotListFilter) active() bool {
+	return f.MinCVSS != 0 || f.MinEPSSPercentile != 0
+}
+
+func (f DependabotListFilter) apply(alerts []model.DependabotAlert) []model.DependabotAlert {
+	if !f.active() {
+		return alerts
+	}
+
+	var filtered []model.DependabotAlert
+	for _, alert := range alerts {
+		if advisoryCVSS(alert.SecurityAdvisory) < f.MinCVSS {
+			continue
+		}
+		if percentile, _ := advisoryEPSS(alert.SecurityAdvisory); percentile < f.MinEPSSPercentile {
+			continue
+		}
+		filtered = append(filtered, alert)
+	}
+	return filtered
+}
+
 // ListDependabotAlerts fetches alerts using your standardized pagination
-func (d *DependencyServices) ListDependabotAlerts(org, repo string, jsonOutput bool, userPageSize int, fetchAll bool) error {
+func (d *DependencyServices) ListDependabotAlerts(org, repo string, filter DependabotListFilter, ownership OwnershipOptions, jsonOutput bool, userPageSize int, fetchAll bool) error {
 	pageSize := GetOptimalPageSize(userPageSize)
 	path := fmt.Sprintf("repos/%s/%s/dependabot/alerts?per_page=%d", org, repo, pageSize)
 
+	resolver, err := newOwnershipResolver(ownership)
+	if err != nil {
+		return err
+	}
+
+	if filter.active() {
+		// Everything is fetched before filtering: use the largest pages
+		path = fmt.Sprintf("repos/%s/%s/dependabot/alerts?per_page=100", org, repo)
+		return d.listFilteredDependabotAlerts(path, org, repo, filter, resolver, ownership, jsonOutput, pageSize, fetchAll)
+	}
+
 	d.alerts = []model.DependabotAlert{}
 
 	for {
@@ -33,6 +74,10 @@ func (d *DependencyServices) ListDependabotAlerts(org, repo string, jsonOutput b
 			return err
 		}
 
+		pageAlerts = assignOwners(resolver, ownership, pageAlerts, func(alert *model.DependabotAlert) (string, string, *[]string) {
+			return org + "/" + repo, alert.Dependency.ManifestPath, &alert.Owners
+		})
+
 		if jsonOutput {
 			d.alerts = append(d.alerts, pageAlerts...)
 			if nextUrl == "" {
@@ -43,7 +88,7 @@ func (d *DependencyServices) ListDependabotAlerts(org, repo string, jsonOutput b
 		}
 
 		d.alerts = pageAlerts
-		if err := d.printTable(); err != nil {
+		if err := d.printTable(ownership.Show); err != nil {
 			return err
 		}
 
@@ -65,39 +110,55 @@ func (d *DependencyServices) ListDependabotAlerts(org, repo string, jsonOutput b
 	return nil
 }
 
-// ExportSBOM fetches the CycloneDX SBOM for the repository
-func (d *DependencyServices) ExportSBOM(org, repo string) error {
-	// The SBOM API returns a large JSON object (CycloneDX format)
-	// We map it to interface{} to preserve the exact structure without
-	// needing massive struct definitions.
-	path := fmt.Sprintf("repos/%s/%s/dependency-graph/sbom", org, repo)
-
-	var sbom map[string]interface{}
-
-	// We can reuse getPages for a single fetch, or just direct client usage.
-	// Since getPages handles the Request/Unmarshal logic nicely, let's use it
-	// even though there's no pagination for SBOMs.
-	_, err := getPages(path, &sbom)
+// listFilteredDependabotAlerts fetches every alert, keeps the ones matching the filter
+// and pages the result, so a page is never emptied by the filter
+func (d *DependencyServices) listFilteredDependabotAlerts(path, org, repo string, filter DependabotListFilter, resolver *OwnershipResolver, ownership OwnershipOptions, jsonOutput bool, pageSize int, fetchAll bool) error {
+	alerts, err := fetchAllPages[model.DependabotAlert](path)
 	if err != nil {
 		return err
 	}
+	alerts = assignOwners(resolver, ownership, filter.apply(alerts), func(alert *model.DependabotAlert) (string, string, *[]string) {
+		return org + "/" + repo, alert.Dependency.ManifestPath, &alert.Owners
+	})
+
+	if jsonOutput {
+		return jsonLister(alerts)
+	}
 
-	// Always output SBOM as JSON (it's a data format)
-	return jsonLister(sbom)
+	for start := 0; ; start += pageSize {
+		end := min(start+pageSize, len(alerts))
+		d.alerts = alerts[start:end]
+		if err := d.printTable(ownership.Show); err != nil {
+			return err
+		}
+		if end == len(alerts) {
+			return nil
+		}
+		if !fetchAll && !AskForNextPage() {
+			return nil
+		}
+	}
 }
 
-func (d *DependencyServices) printTable() error {
+func (d *DependencyServices) printTable(showOwners bool) error {
 	tp, err := getTablePrinter()
 	if err != nil {
 		return err
 	}
 
-	tp.AddHeader([]string{"ID", "State", "Severity", "Package", "CVE/GHSA", "Version Range"})
+	headers := []string{"ID", "State", "Severity", "CVSS", "EPSS", "CWEs", "Package", "CVE/GHSA", "Version Range", "Patched"}
+	if showOwners {
+		headers = append(headers, "Owner")
+	}
+	tp.AddHeader(headers)
 
 	for _, alert := range d.alerts {
 		tp.AddField(fmt.Sprintf("%d", alert.Number))
 		tp.AddField(alert.State)
 		tp.AddField(alert.SecurityAdvisory.Severity)
+		tp.AddField(formatCVSS(alert.SecurityAdvisory))
+		tp.AddField(formatEPSS(alert.SecurityAdvisory))
+		tp.AddField(formatCWEs(alert.SecurityAdvisory, false))
 		tp.AddField(alert.Dependency.Package.Name)
 
 		// Show CVE if available, otherwise GHSA
@@ -108,6 +169,10 @@ func (d *DependencyServices) printTable() error {
 		tp.AddField(id)
 
 		tp.AddField(alert.SecurityVulnerability.VulnerableVersionRange)
+		tp.AddField(formatPatchedVersion(alert.SecurityVulnerability))
+		if showOwners {
+			tp.AddField(formatOwners(alert.Owners))
+		}
 		tp.EndRow()
 	}
 
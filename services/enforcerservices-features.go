@@ -0,0 +1,115 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Repository level features, in the order they must be enabled (push protection and the other
+// secret scanning settings need secret scanning)
+const (
+	FeatureSecretScanning      = "secret-scanning"
+	FeaturePushProtection      = "push-protection"
+	FeatureNonProviderPatterns = "non-provider-patterns"
+	FeatureValidityChecks      = "validity-checks"
+	FeatureDependabot          = "dependabot"
+)
+
+var RepositoryFeatures = []string{FeatureSecretScanning, FeaturePushProtection, FeatureNonProviderPatterns, FeatureValidityChecks, FeatureDependabot}
+
+// ValidateFeatures checks feature names against RepositoryFeatures
+func ValidateFeatures(features []string) error {
+	for _, f := range features {
+		if !slices.Contains(RepositoryFeatures, f) {
+			return fmt.Errorf("unknown feature '%s' (expected %s)", f, strings.Join(RepositoryFeatures, ", "))
+		}
+	}
+	return nil
+}
+
+// SetRepositoryFeature enables or disables a feature of RepositoryFeatures on a single repository.
+// Dependabot covers both the alerts and the security updates, as the enable dependabot command does.
+func (e *EnforcerServices) SetRepositoryFeature(owner, repo, feature string, enabled bool) error {
+	switch feature {
+	case FeatureSecretScanning:
+		if enabled {
+			return e.EnableSecretScanning(owner, repo)
+		}
+		return e.DisableSecretScanning(owner, repo)
+	case FeaturePushProtection:
+		if enabled {
+			return e.EnablePushProtection(owner, repo)
+		}
+		return e.DisablePushProtection(owner, repo)
+	case FeatureNonProviderPatterns:
+		if enabled {
+			return e.EnableSecretScanningNonProviderPatterns(owner, repo)
+		}
+		return e.DisableSecretScanningNonProviderPatterns(owner, repo)
+	case FeatureValidityChecks:
+		if enabled {
+			return e.EnableSecretScanningValidityChecks(owner, repo)
+		}
+		return e.DisableSecretScanningValidityChecks(owner, repo)
+	case FeatureDependabot:
+		if enabled {
+			if err := e.EnableDependabotAlerts(owner, repo); err != nil {
+				return err
+			}
+			return e.EnableDependabotSecurityUpdates(owner, repo)
+		}
+		// Security updates depend on the alerts: turn them off first
+		if err := e.DisableDependabotSecurityUpdates(owner, repo); err != nil {
+			return err
+		}
+		return e.DisableDependabotAlerts(owner, repo)
+	}
+	return ValidateFeatures([]string{feature})
+}
+
+// SetFeatureForRepositories enables or disables a feature on a selection of repositories of an organization
+// (e.g. the ones matching property selectors), as the organization level endpoints apply to every repository
+func (e *EnforcerServices) SetFeatureForRepositories(org string, repos []string, feature string, enabled bool) error {
+	if err := ValidateFeatures([]string{feature}); err != nil {
+		return err
+	}
+
+	action := "Disabling"
+	if enabled {
+		action = "Enabling"
+	}
+	fmt.Printf("%s %s for %d repositories in '%s'...\n", action, feature, len(repos), org)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, 5)
+	failed := 0
+
+	for _, repo := range repos {
+		wg.Add(1)
+		go func(repoName string) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			err := e.SetRepositoryFeature(org, repoName, feature, enabled)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				fmt.Printf("- %s/%s: %s\n", org, repoName, err)
+				return
+			}
+			fmt.Printf("- %s/%s: done\n", org, repoName)
+		}(repo)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed", failed, len(repos))
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// SetPushProtectionCustomLink sets the resource link shown to developers blocked by Push Protection.
+// An empty link with enabled=false only turns the custom link off.
+func (e *EnforcerServices) SetPushProtectionCustomLink(org, link string, enabled bool) error {
+	if enabled && strings.TrimSpace(link) == "" {
+		return errors.New("a custom link can't be enabled without a URL")
+	}
+	settings := model.OrgUpdateRequest{
+		SecretScanningPushProtectionCustomLinkEnabled: boolPtr(enabled),
+	}
+	if link != "" {
+		settings.SecretScanningPushProtectionCustomLink = stringPtr(link)
+	}
+	return e.UpdateOrgSettings(org, settings)
+}
+
+// SetDelegatedBypassReviewers enables delegated bypass on a code security configuration
+// and replaces its reviewers with the given teams (slugs) and organization roles (names).
+// Numeric values are used as IDs directly.
+// Docs: PATCH /orgs/{org}/code-security/configurations/{configuration_id}
+func (e *EnforcerServices) SetDelegatedBypassReviewers(org, configuration string, teams, roles []string) error {
+	config, err := e.FindCodeSecurityConfiguration(org, configuration)
+	if err != nil {
+		return err
+	}
+
+	reviewers := []model.BypassReviewer{}
+	for _, team := range teams {
+		id, err := e.resolveTeamID(org, team)
+		if err != nil {
+			return err
+		}
+		reviewers = append(reviewers, model.BypassReviewer{ReviewerID: id, ReviewerType: "TEAM"})
+	}
+	for _, role := range roles {
+		id, err := e.resolveRoleID(org, role)
+		if err != nil {
+			return err
+		}
+		reviewers = append(reviewers, model.BypassReviewer{ReviewerID: id, ReviewerType: "ROLE"})
+	}
+
+	state := "enabled"
+	if len(reviewers) == 0 {
+		state = "disabled"
+	}
+
+	path := fmt.Sprintf("orgs/%s/code-security/configurations/%d", org, config.ID)
+	payload := model.CodeSecurityConfigurationUpdateRequest{
+		SecretScanningDelegatedBypass:        stringPtr(state),
+		SecretScanningDelegatedBypassOptions: &model.DelegatedBypassOptions{Reviewers: reviewers},
+	}
+	return patch(path, payload)
+}
+
+// FindCodeSecurityConfiguration looks up an organization code security configuration by name or ID
+// Docs: GET /orgs/{org}/code-security/configurations
+func (e *EnforcerServices) FindCodeSecurityConfiguration(org, nameOrID string) (*model.CodeSecurityConfiguration, error) {
+	path := fmt.Sprintf("orgs/%s/code-security/configurations?per_page=100", org)
+
+	for {
+		var pageConfigs []model.CodeSecurityConfiguration
+		nextUrl, err := getPages(path, &pageConfigs)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range pageConfigs {
+			if strings.EqualFold(c.Name, nameOrID) || strconv.Itoa(c.ID) == nameOrID {
+				return &c, nil
+			}
+		}
+		if nextUrl == "" {
+			break
+		}
+		path = nextUrl
+	}
+	return nil, fmt.Errorf("code security configuration '%s' not found in '%s'", nameOrID, org)
+}
+
+func (e *EnforcerServices) resolveTeamID(org, team string) (int, error) {
+	if id, err := strconv.Atoi(team); err == nil {
+		return id, nil
+	}
+
+	t := &model.Team{}
+	if err := client.Get(fmt.Sprintf("orgs/%s/teams/%s", org, team), t); err != nil {
+		return 0, fmt.Errorf("failed to find team '%s': %w", team, err)
+	}
+	return t.ID, nil
+}
+
+func (e *EnforcerServices) resolveRoleID(org, role string) (int, error) {
+	if id, err := strconv.Atoi(role); err == nil {
+		return id, nil
+	}
+
+	roles := &model.OrganizationRoles{}
+	if err := client.Get(fmt.Sprintf("orgs/%s/organization-roles", org), roles); err != nil {
+		return 0, fmt.Errorf("failed to list organization roles: %w", err)
+	}
+	for _, r := range roles.Roles {
+		if strings.EqualFold(r.Name, role) {
+			return r.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("organization role '%s' not found in '%s'", role, org)
+}
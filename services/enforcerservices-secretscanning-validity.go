@@ -0,0 +1,78 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// EnableSecretScanningValidityChecks enables automatic validity checks of detected partner tokens
+func (e *EnforcerServices) EnableSecretScanningValidityChecks(owner, repo string) error {
+	path := fmt.Sprintf("repos/%s/%s", owner, repo)
+
+	payload := model.RepoUpdateRequest{
+		SecurityAndAnalysis: &model.SecurityAndAnalysisReq{
+			SecretScanningValidityChecks: &model.StatusReq{Status: "enabled"},
+		},
+	}
+
+	return patch(path, payload)
+}
+
+func (e *EnforcerServices) DisableSecretScanningValidityChecks(owner, repo string) error {
+	path := fmt.Sprintf("repos/%s/%s", owner, repo)
+	payload := model.RepoUpdateRequest{
+		SecurityAndAnalysis: &model.SecurityAndAnalysisReq{
+			SecretScanningValidityChecks: &model.StatusReq{Status: "disabled"},
+		},
+	}
+	return patch(path, payload)
+}
+
+// BulkEnableSecretScanningValidityChecks enables validity checks on every (non archived) repository of an organization.
+// Organizations have no setting for them (nor for new repositories): they are set repository by repository,
+// on the repositories with Secret Scanning enabled, as they don't apply elsewhere.
+func (e *EnforcerServices) BulkEnableSecretScanningValidityChecks(org string) error {
+	return e.setValidityChecksForOrg(org, true)
+}
+
+func (e *EnforcerServices) BulkDisableSecretScanningValidityChecks(org string) error {
+	return e.setValidityChecksForOrg(org, false)
+}
+
+func (e *EnforcerServices) setValidityChecksForOrg(org string, enabled bool) error {
+	repos, err := GetRepositoryServices().FetchAllForOrg(org)
+	if err != nil {
+		return err
+	}
+
+	action := "Disabling"
+	if enabled {
+		action = "Enabling"
+	}
+
+	total, failed := 0, 0
+	for _, r := range repos {
+		if r.Archived || r.SecurityAndAnalysis.SecretScanning.Status != "enabled" {
+			continue
+		}
+		total++
+		if enabled {
+			err = e.EnableSecretScanningValidityChecks(org, r.Name)
+		} else {
+			err = e.DisableSecretScanningValidityChecks(org, r.Name)
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("- %s/%s: %s\n", org, r.Name, err)
+			continue
+		}
+		fmt.Printf("- %s/%s: done\n", org, r.Name)
+	}
+	fmt.Printf("%s Secret Scanning Validity Checks: %d repositories with Secret Scanning in '%s'\n", action, total, org)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed", failed, total)
+	}
+	return nil
+}
@@ -53,3 +53,8 @@ func (e *EnforcerServices) UpdateOrgSettings(org string, settings model.OrgUpdat
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+// Helper simples para criar ponteiros string
+func stringPtr(s string) *string {
+	return &s
+}
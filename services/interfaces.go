@@ -1,5 +1,7 @@
 package services
 
+import "github.com/messagedigest-net/gh-advanced-security/model"
+
 type Lister interface {
 	// List now accepts jsonOutput (bool) , userPageSize (int), and fetchAll (bool)
 	List(bool, int, bool) error
@@ -14,3 +16,9 @@ type Shower interface {
 	// Show remains unchanged: name (string), jsonOutput (bool)
 	Show(string, bool) error
 }
+
+// Notifier delivers alert events (new, reopened, resolved) to an external system
+type Notifier interface {
+	Name() string
+	Notify([]model.AlertEvent) error
+}
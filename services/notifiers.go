@@ -0,0 +1,310 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"github.com/spf13/viper"
+)
+
+var notifierClient = &http.Client{Timeout: 30 * time.Second}
+
+// LoadNotifiers builds the notifiers of the "notifiers" section of the config file.
+// When names is not empty only those notifiers are returned.
+func LoadNotifiers(names []string) ([]Notifier, error) {
+	var configs []model.NotifierConfig
+	if err := viper.UnmarshalKey("notifiers", &configs); err != nil {
+		return nil, err
+	}
+
+	notifiers := []Notifier{}
+	for _, c := range configs {
+		if len(names) > 0 && !slices.Contains(names, c.Name) {
+			continue
+		}
+		n, err := NewNotifier(c)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+
+	if len(notifiers) == 0 {
+		if len(names) > 0 {
+			return nil, fmt.Errorf("notifiers not found in the config file: %s", strings.Join(names, ", "))
+		}
+		return nil, fmt.Errorf("no notifiers configured: add a 'notifiers' section to the config file")
+	}
+	return notifiers, nil
+}
+
+// NewNotifier creates the notifier for a config entry
+func NewNotifier(c model.NotifierConfig) (Notifier, error) {
+	c.URL = os.ExpandEnv(c.URL)
+	c.Token = os.ExpandEnv(c.Token)
+	for k, v := range c.Headers {
+		c.Headers[k] = os.ExpandEnv(v)
+	}
+	if c.Name == "" {
+		c.Name = c.Type
+	}
+	if c.URL == "" {
+		return nil, fmt.Errorf("notifier '%s': url is required", c.Name)
+	}
+	if len(c.Events) == 0 {
+		c.Events = []string{AlertEventNew, AlertEventReopened}
+	}
+	if c.MinSeverity != "" && AlertSeverityRank(c.MinSeverity) < 0 {
+		return nil, fmt.Errorf("notifier '%s': invalid min_severity '%s'", c.Name, c.MinSeverity)
+	}
+	repositories, err := regexp.Compile(c.Repositories)
+	if err != nil {
+		return nil, fmt.Errorf("notifier '%s': invalid repositories expression: %w", c.Name, err)
+	}
+
+	base := notifierFilter{config: c, repositories: repositories}
+	switch c.Type {
+	case "webhook":
+		return &webhookNotifier{base}, nil
+	case "slack", "teams":
+		return &chatNotifier{base}, nil
+	case "jira":
+		if c.Project == "" {
+			return nil, fmt.Errorf("notifier '%s': project is required for jira", c.Name)
+		}
+		if c.IssueType == "" {
+			c.IssueType = "Bug"
+			base.config = c
+		}
+		return &jiraNotifier{base}, nil
+	}
+	return nil, fmt.Errorf("notifier '%s': unknown type '%s' (expected webhook, slack, teams or jira)", c.Name, c.Type)
+}
+
+// Notify sends the events to every notifier, each receiving only the events matching its filters.
+// Returns the first error, after trying every notifier.
+func Notify(notifiers []Notifier, events []model.AlertEvent) error {
+	_, err := NotifyPending(notifiers, nil, events)
+	return err
+}
+
+// NotifyPending sends to every notifier the events it failed to receive before (pending, by notifier name)
+// and the new events. Returns the events each notifier still has to receive, so a failing notifier gets
+// them again without the others being notified twice, and the first error.
+func NotifyPending(notifiers []Notifier, pending map[string][]model.AlertEvent, events []model.AlertEvent) (map[string][]model.AlertEvent, error) {
+	left := map[string][]model.AlertEvent{}
+	for name, p := range pending {
+		left[name] = p // notifiers not selected by this run keep their events
+	}
+
+	var firstErr error
+	for _, n := range notifiers {
+		batch := append(slices.Clone(pending[n.Name()]), events...)
+		delete(left, n.Name())
+		if len(batch) == 0 {
+			continue
+		}
+		if err := n.Notify(batch); err != nil {
+			fmt.Fprintf(os.Stderr, "- %s: %s\n", n.Name(), err)
+			var partial *undeliveredError
+			if errors.As(err, &partial) {
+				batch = partial.events
+			}
+			left[n.Name()] = batch
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return left, firstErr
+}
+
+// undeliveredError is returned by a notifier that delivered part of the events: only the others are sent again
+type undeliveredError struct {
+	err    error
+	events []model.AlertEvent
+}
+
+func (e *undeliveredError) Error() string {
+	return fmt.Sprintf("%v (%d events not delivered)", e.err, len(e.events))
+}
+
+func (e *undeliveredError) Unwrap() error {
+	return e.err
+}
+
+// notifierFilter holds the config of a notifier and selects the events it receives
+type notifierFilter struct {
+	config       model.NotifierConfig
+	repositories *regexp.Regexp
+}
+
+func (f notifierFilter) Name() string {
+	return f.config.Name
+}
+
+func (f notifierFilter) filter(events []model.AlertEvent) []model.AlertEvent {
+	selected := []model.AlertEvent{}
+	for _, e := range events {
+		if !slices.Contains(f.config.Events, e.Event) || !f.repositories.MatchString(e.Alert.Repository) {
+			continue
+		}
+		if len(f.config.Types) > 0 && !slices.Contains(f.config.Types, e.Alert.Type) {
+			continue
+		}
+		// Resolved alerts are no longer listed, so their severity is unknown
+		if e.Event != AlertEventResolved && AlertSeverityRank(e.Alert.Severity) < AlertSeverityRank(f.config.MinSeverity) {
+			continue
+		}
+		selected = append(selected, e)
+	}
+	return selected
+}
+
+// webhookNotifier posts the events as JSON: {"count": n, "events": [...]}
+type webhookNotifier struct {
+	notifierFilter
+}
+
+func (w *webhookNotifier) Notify(events []model.AlertEvent) error {
+	events = w.filter(events)
+	if len(events) == 0 {
+		return nil
+	}
+	payload := map[string]interface{}{"count": len(events), "events": events}
+	return postNotification(w.config.URL, w.config.Headers, payload)
+}
+
+// chatNotifier posts a message to a Slack or Microsoft Teams incoming webhook
+type chatNotifier struct {
+	notifierFilter
+}
+
+func (c *chatNotifier) Notify(events []model.AlertEvent) error {
+	events = c.filter(events)
+	if len(events) == 0 {
+		return nil
+	}
+
+	lines := []string{fmt.Sprintf("%d security alert change(s)", len(events))}
+	for _, e := range events {
+		a := e.Alert
+		text := fmt.Sprintf("%s %s #%d", a.Repository, a.Type, a.Number)
+		if c.config.Type == "slack" {
+			text = fmt.Sprintf("<%s|%s>", a.URL, text)
+		} else {
+			text = fmt.Sprintf("[%s](%s)", text, a.URL)
+		}
+		if e.Event == AlertEventResolved {
+			lines = append(lines, fmt.Sprintf("• %s: %s", e.Event, text))
+		} else {
+			lines = append(lines, fmt.Sprintf("• %s [%s] %s: %s", e.Event, a.Severity, text, a.Title))
+		}
+	}
+	message := strings.Join(lines, "\n")
+
+	var payload interface{} = map[string]string{"text": message}
+	if c.config.Type == "teams" {
+		payload = map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  lines[0],
+			"text":     strings.ReplaceAll(message, "\n", "\n\n"),
+		}
+	}
+	return postNotification(c.config.URL, c.config.Headers, payload)
+}
+
+// jiraNotifier opens a Jira issue per new or reopened alert
+// Docs: POST {jira}/rest/api/2/issue
+type jiraNotifier struct {
+	notifierFilter
+}
+
+func (j *jiraNotifier) Notify(events []model.AlertEvent) error {
+	headers := map[string]string{}
+	for k, v := range j.config.Headers {
+		headers[k] = v
+	}
+	if j.config.Token != "" {
+		if j.config.User != "" {
+			headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(j.config.User+":"+j.config.Token))
+		} else {
+			headers["Authorization"] = "Bearer " + j.config.Token
+		}
+	}
+
+	selected := j.filter(events)
+	for i, e := range selected {
+		if e.Event == AlertEventResolved {
+			continue
+		}
+		a := e.Alert
+		fields := map[string]interface{}{
+			"project":   map[string]string{"key": j.config.Project},
+			"issuetype": map[string]string{"name": j.config.IssueType},
+			"summary":   fmt.Sprintf("[%s] %s %s alert #%d: %s", a.Severity, a.Repository, a.Type, a.Number, a.Title),
+			"description": fmt.Sprintf("%s alert (%s)\nRepository: %s\nRule: %s\nLocation: %s\n%s",
+				a.Type, e.Event, a.Repository, a.Rule, alertLocation(a), a.URL),
+		}
+		if len(j.config.Labels) > 0 {
+			fields["labels"] = j.config.Labels
+		}
+
+		url := strings.TrimSuffix(j.config.URL, "/") + "/rest/api/2/issue"
+		if err := postNotification(url, headers, map[string]interface{}{"fields": fields}); err != nil {
+			// The issues already created must not be created again
+			return &undeliveredError{err: err, events: selected[i:]}
+		}
+	}
+	return nil
+}
+
+func alertLocation(a model.SecurityAlert) string {
+	switch {
+	case a.Path == "":
+		return "-"
+	case a.Line > 0:
+		return fmt.Sprintf("%s:%d", a.Path, a.Line)
+	}
+	return a.Path
+}
+
+func postNotification(url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := notifierClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("HTTP %d from %s: %s", resp.StatusCode, url, strings.TrimSpace(string(respBody)))
+}
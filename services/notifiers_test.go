@@ -0,0 +1,125 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// fakeNotifier records the events it receives and fails on the events after the first accepted ones
+type fakeNotifier struct {
+	name     string
+	accepted int // events delivered before failing, -1 to deliver them all
+	partial  bool
+	received []int
+}
+
+func (f *fakeNotifier) Name() string {
+	return f.name
+}
+
+func (f *fakeNotifier) Notify(events []model.AlertEvent) error {
+	for _, e := range events {
+		f.received = append(f.received, e.Alert.Number)
+	}
+	if f.accepted < 0 || f.accepted >= len(events) {
+		return nil
+	}
+	err := errors.New("unavailable")
+	if f.partial {
+		return &undeliveredError{err: err, events: events[f.accepted:]}
+	}
+	return err
+}
+
+func TestNotifyPending(t *testing.T) {
+	event := func(number int) model.AlertEvent {
+		return model.AlertEvent{Event: AlertEventNew, Alert: model.SecurityAlert{Repository: "org/api", Type: "code-scanning", Number: number}}
+	}
+	numbers := func(events []model.AlertEvent) []int {
+		n := []int{}
+		for _, e := range events {
+			n = append(n, e.Alert.Number)
+		}
+		return n
+	}
+
+	tests := []struct {
+		name      string
+		notifiers []*fakeNotifier
+		pending   map[string][]model.AlertEvent
+		events    []model.AlertEvent
+		received  map[string][]int
+		left      map[string][]int
+		wantErr   bool
+	}{
+		{
+			name:      "all delivered",
+			notifiers: []*fakeNotifier{{name: "slack", accepted: -1}, {name: "jira", accepted: -1}},
+			events:    []model.AlertEvent{event(1), event(2)},
+			received:  map[string][]int{"slack": {1, 2}, "jira": {1, 2}},
+			left:      map[string][]int{},
+		},
+		{
+			name:      "failing notifier keeps its events",
+			notifiers: []*fakeNotifier{{name: "slack", accepted: -1}, {name: "webhook", accepted: 0}},
+			events:    []model.AlertEvent{event(1), event(2)},
+			received:  map[string][]int{"slack": {1, 2}, "webhook": {1, 2}},
+			left:      map[string][]int{"webhook": {1, 2}},
+			wantErr:   true,
+		},
+		{
+			name:      "partial delivery keeps only the undelivered events",
+			notifiers: []*fakeNotifier{{name: "slack", accepted: -1}, {name: "jira", accepted: 2, partial: true}},
+			pending:   map[string][]model.AlertEvent{"jira": {event(1)}},
+			events:    []model.AlertEvent{event(2), event(3)},
+			received:  map[string][]int{"slack": {2, 3}, "jira": {1, 2, 3}},
+			left:      map[string][]int{"jira": {3}},
+			wantErr:   true,
+		},
+		{
+			name:      "pending events are sent again without new events",
+			notifiers: []*fakeNotifier{{name: "slack", accepted: -1}, {name: "jira", accepted: -1}},
+			pending:   map[string][]model.AlertEvent{"jira": {event(1)}},
+			received:  map[string][]int{"jira": {1}},
+			left:      map[string][]int{},
+		},
+		{
+			name:      "unselected notifiers keep their events",
+			notifiers: []*fakeNotifier{{name: "slack", accepted: -1}},
+			pending:   map[string][]model.AlertEvent{"jira": {event(1)}},
+			events:    []model.AlertEvent{event(2)},
+			received:  map[string][]int{"slack": {2}},
+			left:      map[string][]int{"jira": {1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifiers := []Notifier{}
+			for _, n := range tt.notifiers {
+				notifiers = append(notifiers, n)
+			}
+
+			left, err := NotifyPending(notifiers, tt.pending, tt.events)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, want error %v", err, tt.wantErr)
+			}
+			for _, n := range tt.notifiers {
+				if !slices.Equal(n.received, tt.received[n.name]) {
+					t.Errorf("%s received %v, want %v", n.name, n.received, tt.received[n.name])
+				}
+			}
+			if len(left) != len(tt.left) {
+				t.Errorf("left = %v, want %v", left, tt.left)
+			}
+			for name, want := range tt.left {
+				if got := numbers(left[name]); !slices.Equal(got, want) {
+					t.Errorf("left[%s] = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"github.com/spf13/viper"
+)
+
+// Ownership sources, tried in the configured order until one returns owners
+const (
+	OwnershipCodeowners = "codeowners" // CODEOWNERS rules matching the file of the alert
+	OwnershipProperty   = "property"   // a custom repository property naming the owning team(s)
+	OwnershipTeams      = "teams"      // teams with the configured permissions on the repository
+)
+
+var OwnershipSources = []string{OwnershipCodeowners, OwnershipProperty, OwnershipTeams}
+
+// OwnershipOptions are the ownership flags of the list and report commands
+type OwnershipOptions struct {
+	Show  bool     // add an Owner column
+	Teams []string // only the alerts owned by one of these teams (or users)
+}
+
+// Enabled reports whether owners must be resolved
+func (o OwnershipOptions) Enabled() bool {
+	return o.Show || len(o.Teams) > 0
+}
+
+// OwnershipResolver maps alerts to their owners. It is configured in the config file:
+//
+//	ownership:
+//	  sources: [codeowners, property, teams]   # first source with owners wins
+//	  property: owner                           # custom property naming the owning team(s)
+//	  permissions: [admin, maintain]            # team permissions making a team an owner
+//
+// The data of each repository is fetched once, and the resolver is safe for concurrent use.
+type OwnershipResolver struct {
+	sources     []string
+	property    string
+	permissions []string
+
+	mu    sync.Mutex
+	repos map[string]*repositoryOwnership
+}
+
+type repositoryOwnership struct {
+	once       sync.Once
+	codeowners Codeowners
+	property   []string
+	teams      []string
+}
+
+// NewOwnershipResolver creates a resolver from the "ownership" section of the config file
+func NewOwnershipResolver() (*OwnershipResolver, error) {
+	viper.SetDefault("ownership.sources", OwnershipSources)
+	viper.SetDefault("ownership.property", "owner")
+	viper.SetDefault("ownership.permissions", []string{"admin", "maintain"})
+
+	o := &OwnershipResolver{
+		sources:     viper.GetStringSlice("ownership.sources"),
+		property:    viper.GetString("ownership.property"),
+		permissions: viper.GetStringSlice("ownership.permissions"),
+		repos:       map[string]*repositoryOwnership{},
+	}
+	for _, s := range o.sources {
+		if !slices.Contains(OwnershipSources, s) {
+			return nil, fmt.Errorf("unknown ownership source '%s' (expected %s)", s, strings.Join(OwnershipSources, ", "))
+		}
+	}
+	return o, nil
+}
+
+// Owners returns the owners ("@org/team", "@user" or e-mail) of a file of a repository ("owner/repo").
+// path may be empty for alerts without a file, then only the repository level sources apply.
+func (o *OwnershipResolver) Owners(repository, path string) []string {
+	info := o.load(repository)
+	for _, source := range o.sources {
+		var owners []string
+		switch source {
+		case OwnershipCodeowners:
+			if path != "" {
+				owners = info.codeowners.Owners(path)
+			}
+		case OwnershipProperty:
+			owners = info.property
+		case OwnershipTeams:
+			owners = info.teams
+		}
+		if len(owners) > 0 {
+			return owners
+		}
+	}
+	return nil
+}
+
+// load fetches the ownership data of a repository on first use. A source that can't be read
+// (no CODEOWNERS, no access to the teams...) just resolves no owner; a CODEOWNERS file that
+// exists but can't be read is reported on stderr.
+func (o *OwnershipResolver) load(repository string) *repositoryOwnership {
+	o.mu.Lock()
+	info, ok := o.repos[repository]
+	if !ok {
+		info = &repositoryOwnership{}
+		o.repos[repository] = info
+	}
+	o.mu.Unlock()
+
+	info.once.Do(func() {
+		owner, repo, _ := strings.Cut(repository, "/")
+		svc := GetRepositoryServices()
+
+		if slices.Contains(o.sources, OwnershipCodeowners) {
+			codeowners, err := svc.GetCodeowners(owner, repo)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Unable to read the CODEOWNERS of %s: %v\n", repository, err)
+			}
+			info.codeowners = codeowners
+		}
+		if slices.Contains(o.sources, OwnershipProperty) {
+			if values, err := svc.GetCustomPropertyValues(owner, repo); err == nil {
+				for _, v := range values {
+					if v.PropertyName == o.property {
+						for _, name := range propertyValues(v.Value) {
+							info.property = append(info.property, normalizeOwner(owner, name))
+						}
+					}
+				}
+			}
+		}
+		if slices.Contains(o.sources, OwnershipTeams) {
+			if teams, err := svc.GetTeams(owner, repo); err == nil {
+				for _, t := range teams {
+					if slices.Contains(o.permissions, t.Permission) {
+						info.teams = append(info.teams, "@"+owner+"/"+t.Slug)
+					}
+				}
+			}
+		}
+	})
+	return info
+}
+
+// propertyValues flattens a custom property value (string, or list for multi_select properties)
+func propertyValues(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []interface{}:
+		values := []string{}
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// normalizeOwner writes a team named in a property ("payments" or "my-org/payments") like CODEOWNERS does
+func normalizeOwner(org, name string) string {
+	name = strings.TrimSpace(name)
+	switch {
+	case strings.HasPrefix(name, "@"), strings.Contains(name, "@"):
+		return name
+	case strings.Contains(name, "/"):
+		return "@" + name
+	}
+	return "@" + org + "/" + name
+}
+
+// OwnedBy reports whether one of the owners is one of the teams. Teams can be given as
+// "@org/team", "org/team" or just "team" (and users as "@user" or "user").
+func OwnedBy(owners []string, teams []string) bool {
+	for _, owner := range owners {
+		owner = strings.ToLower(strings.TrimPrefix(owner, "@"))
+		for _, team := range teams {
+			team = strings.ToLower(strings.TrimPrefix(team, "@"))
+			if owner == team || strings.HasSuffix(owner, "/"+team) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// secretScanningPath returns the file where a secret was first found (empty when not in a commit)
+func secretScanningPath(alert model.SecretScanningAlert) string {
+	if alert.FirstLocationDetected != nil {
+		return alert.FirstLocationDetected.Path
+	}
+	for _, l := range alert.Locations {
+		if l.Type == "commit" {
+			return l.Details.Path
+		}
+	}
+	return ""
+}
+
+// formatOwners renders the owners for a table column
+func formatOwners(owners []string) string {
+	if len(owners) == 0 {
+		return "-"
+	}
+	return strings.Join(owners, " ")
+}
+
+// assignOwners resolves the owners of the alerts and keeps the ones owned by opts.Teams (all when empty).
+// locate returns the repository and file of an alert, and where to store its owners.
+func assignOwners[T any](resolver *OwnershipResolver, opts OwnershipOptions, alerts []T, locate func(*T) (repository, path string, owners *[]string)) []T {
+	if resolver == nil {
+		return alerts
+	}
+	kept := []T{}
+	for i := range alerts {
+		repository, path, owners := locate(&alerts[i])
+		*owners = resolver.Owners(repository, path)
+		if len(opts.Teams) == 0 || OwnedBy(*owners, opts.Teams) {
+			kept = append(kept, alerts[i])
+		}
+	}
+	return kept
+}
+
+// newOwnershipResolver returns a resolver when the options need one (nil otherwise)
+func newOwnershipResolver(opts OwnershipOptions) (*OwnershipResolver, error) {
+	if !opts.Enabled() {
+		return nil, nil
+	}
+	return NewOwnershipResolver()
+}
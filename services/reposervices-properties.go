@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// PropertySelectors select repositories by custom property (property name -> accepted values).
+// Values of the same property are OR-ed, different properties are AND-ed.
+type PropertySelectors map[string][]string
+
+// ParsePropertySelectors parses "key=value" selectors (e.g. --property business-unit=payments)
+func ParsePropertySelectors(selectors []string) (PropertySelectors, error) {
+	parsed := PropertySelectors{}
+	for _, s := range selectors {
+		key, value, found := strings.Cut(s, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid property selector '%s' (expected key=value)", s)
+		}
+		parsed[key] = append(parsed[key], strings.TrimSpace(value))
+	}
+	return parsed, nil
+}
+
+// Matches reports whether the properties of a repository satisfy every selector.
+// Values are compared case insensitively; a multi_select property matches when one of its values does.
+func (s PropertySelectors) Matches(properties map[string][]string) bool {
+	for key, accepted := range s {
+		matched := false
+		for _, value := range properties[key] {
+			for _, a := range accepted {
+				if strings.EqualFold(value, a) {
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// FetchPropertyValues returns the custom property values of every repository of an organization,
+// by repository name then property name
+// Docs: GET /orgs/{org}/properties/values
+func (r *RepositoryServices) FetchPropertyValues(org string) (map[string]map[string][]string, error) {
+	repos, err := fetchAllPages[model.RepositoryPropertyValues](fmt.Sprintf("orgs/%s/properties/values?per_page=100", org))
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]map[string][]string{}
+	for _, repo := range repos {
+		properties := map[string][]string{}
+		for _, p := range repo.Properties {
+			if v := propertyValues(p.Value); len(v) > 0 {
+				properties[p.PropertyName] = v
+			}
+		}
+		values[repo.RepositoryName] = properties
+	}
+	return values, nil
+}
+
+// SelectRepositories returns the names of the repositories of an organization matching the selectors
+func (r *RepositoryServices) SelectRepositories(org string, selectors PropertySelectors) ([]string, error) {
+	values, err := r.FetchPropertyValues(org)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name, properties := range values {
+		if selectors.Matches(properties) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
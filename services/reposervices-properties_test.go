@@ -0,0 +1,71 @@
+package services
+
+import "testing"
+
+func TestPropertySelectorsMatches(t *testing.T) {
+	properties := map[string][]string{
+		"criticality":   {"high"},
+		"business-unit": {"Payments"},
+		"languages":     {"go", "typescript"},
+	}
+
+	tests := []struct {
+		name      string
+		selectors []string
+		want      bool
+	}{
+		{"no selector", nil, true},
+		{"single value", []string{"criticality=high"}, true},
+		{"other value", []string{"criticality=low"}, false},
+		{"case insensitive value", []string{"business-unit=payments"}, true},
+		{"every key must match", []string{"criticality=high", "business-unit=payments"}, true},
+		{"one key not matching", []string{"criticality=high", "business-unit=retail"}, false},
+		{"repeated key is any of", []string{"criticality=low", "criticality=high"}, true},
+		{"multi select", []string{"languages=typescript"}, true},
+		{"multi select not matching", []string{"languages=java"}, false},
+		{"unset property", []string{"owner=payments"}, false},
+		{"spaces around", []string{" criticality = high "}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selectors, err := ParsePropertySelectors(tt.selectors)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := selectors.Matches(properties); got != tt.want {
+				t.Errorf("Matches(%v) = %v, want %v", tt.selectors, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePropertySelectorsErrors(t *testing.T) {
+	for _, selector := range []string{"criticality", "=high", " =high"} {
+		if _, err := ParsePropertySelectors([]string{selector}); err == nil {
+			t.Errorf("ParsePropertySelectors(%q) returned no error", selector)
+		}
+	}
+}
+
+func TestPropertyValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  int
+	}{
+		{"string", "high", 1},
+		{"empty string", "", 0},
+		{"multi select", []interface{}{"go", "", "typescript"}, 2},
+		{"null", nil, 0},
+		{"boolean", true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := propertyValues(tt.value); len(got) != tt.want {
+				t.Errorf("propertyValues(%v) = %v, want %d values", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"github.com/spf13/viper"
+)
+
+// Coverage features checked by the risk score
+const (
+	CoverageCodeScanning   = "code-scanning"
+	CoverageSecretScanning = "secret-scanning"
+	CoveragePushProtection = "push-protection"
+	CoverageDependabot     = "dependabot-security-updates"
+)
+
+// DefaultRiskWeights are used for the weights missing from the "risk" section of the config file
+func DefaultRiskWeights() model.RiskWeights {
+	return model.RiskWeights{
+		Severity:       map[string]float64{"critical": 10, "high": 5, "medium": 2, "low": 1},
+		ActiveSecret:   10,
+		EPSS:           10,
+		MissingFeature: 5,
+		Visibility:     map[string]float64{"public": 2, "internal": 1.5, "private": 1},
+		Properties:     map[string]map[string]float64{},
+	}
+}
+
+// LoadRiskWeights reads the "risk" section of the config file over the default weights:
+//
+//	risk:
+//	  severity: {critical: 10, high: 5, medium: 2, low: 1}
+//	  active_secret: 10
+//	  epss: 10
+//	  missing_feature: 5
+//	  visibility: {public: 2, internal: 1.5, private: 1}
+//	  properties:
+//	    criticality: {high: 2, low: 0.5}
+func LoadRiskWeights() (model.RiskWeights, error) {
+	weights := DefaultRiskWeights()
+	if err := viper.UnmarshalKey("risk", &weights); err != nil {
+		return weights, fmt.Errorf("invalid risk weights: %w", err)
+	}
+	return weights, nil
+}
+
+// ScoreRepositories computes the risk score of every (non archived) repository of an organization,
+// highest first
+func (r *RepositoryServices) ScoreRepositories(org string, weights model.RiskWeights) ([]model.RepositoryRisk, error) {
+	repos, err := r.FetchAllForOrg(org)
+	if err != nil {
+		return nil, err
+	}
+
+	// Progress goes to stderr so JSON output stays parseable
+	fmt.Fprintf(os.Stderr, "Scoring %d repositories of %s...\n", len(repos), org)
+
+	// Organization level listings: an alert type that can't be read (not enabled, no access) is skipped
+	alerts := map[string][]model.SecurityAlert{}
+	for _, t := range AlertTypes {
+		found, err := GetAlertServices().FetchSecurityAlerts(org, []string{t}, "open")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s alerts: %v\n", t, err)
+			continue
+		}
+		for _, a := range found {
+			alerts[a.Repository] = append(alerts[a.Repository], a)
+		}
+	}
+
+	properties := map[string]map[string][]string{}
+	if len(weights.Properties) > 0 {
+		if properties, err = r.FetchPropertyValues(org); err != nil {
+			return nil, err
+		}
+	}
+
+	codeScanning := r.codeScanningCoverage(org, repos)
+
+	risks := []model.RepositoryRisk{}
+	for _, repo := range repos {
+		if repo.Archived {
+			continue
+		}
+		fullName := org + "/" + repo.Name
+		risks = append(risks, scoreRepository(fullName, repo, alerts[fullName], codeScanning[repo.Name], properties[repo.Name], weights))
+	}
+
+	sort.SliceStable(risks, func(i, j int) bool {
+		if risks[i].Score != risks[j].Score {
+			return risks[i].Score > risks[j].Score
+		}
+		return risks[i].Repository < risks[j].Repository
+	})
+	return risks, nil
+}
+
+// codeScanningCoverage checks which repositories have code scanning analyses (nil when it can't be told)
+// Docs: GET /repos/{owner}/{repo}/code-scanning/analyses
+func (r *RepositoryServices) codeScanningCoverage(org string, repos []model.Repository) map[string]*bool {
+	coverage := map[string]*bool{}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, 5)
+
+	for _, repo := range repos {
+		if repo.Archived {
+			continue
+		}
+		wg.Add(1)
+		go func(repoName string) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			var analyses []model.Analysis
+			_, err := getPages(fmt.Sprintf("repos/%s/%s/code-scanning/analyses?per_page=1", org, repoName), &analyses)
+
+			var enabled *bool
+			switch {
+			case err == nil:
+				enabled = boolPtr(len(analyses) > 0)
+			case isNotFound(err):
+				enabled = boolPtr(false)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			coverage[repoName] = enabled
+		}(repo.Name)
+	}
+	wg.Wait()
+	return coverage
+}
+
+func scoreRepository(fullName string, repo model.Repository, alerts []model.SecurityAlert, codeScanning *bool, properties map[string][]string, weights model.RiskWeights) model.RepositoryRisk {
+	risk := model.RepositoryRisk{
+		Repository:      fullName,
+		Visibility:      repositoryVisibility(repo),
+		Alerts:          map[string]int{},
+		MissingFeatures: []string{},
+		Properties:      properties,
+	}
+
+	for _, a := range alerts {
+		risk.Alerts[a.Severity]++
+		risk.Breakdown.Alerts += weights.Severity[a.Severity]
+		if a.Type == AlertTypeSecretScanning && a.Validity == "active" {
+			risk.ActiveSecrets++
+			risk.Breakdown.Secrets += weights.ActiveSecret
+		}
+		if a.Type == AlertTypeDependabot {
+			risk.MaxEPSS = math.Max(risk.MaxEPSS, a.EPSS)
+			risk.Breakdown.EPSS += weights.EPSS * a.EPSS
+		}
+	}
+
+	// security_and_analysis is only returned to admins: an empty status is unknown, not missing
+	features := repo.SecurityAndAnalysis
+	if codeScanning != nil && !*codeScanning {
+		risk.MissingFeatures = append(risk.MissingFeatures, CoverageCodeScanning)
+	}
+	for feature, status := range map[string]string{
+		CoverageSecretScanning: features.SecretScanning.Status,
+		CoveragePushProtection: features.SecretScanningPushProtection.Status,
+		CoverageDependabot:     features.DependabotSecurityUpdates.Status,
+	} {
+		if status == "disabled" {
+			risk.MissingFeatures = append(risk.MissingFeatures, feature)
+		}
+	}
+	sort.Strings(risk.MissingFeatures)
+	risk.Breakdown.Coverage = weights.MissingFeature * float64(len(risk.MissingFeatures))
+
+	risk.Breakdown.VisibilityFactor = 1
+	if factor, ok := weights.Visibility[risk.Visibility]; ok {
+		risk.Breakdown.VisibilityFactor = factor
+	}
+
+	// Config keys are lower case: compare the property names and values case insensitively.
+	// A multi_select property uses its highest multiplier.
+	risk.Breakdown.PropertyFactor = 1
+	for name, values := range properties {
+		multipliers := weights.Properties[strings.ToLower(name)]
+		factor := 0.0
+		for _, v := range values {
+			if m, ok := multipliers[strings.ToLower(v)]; ok {
+				factor = math.Max(factor, m)
+			}
+		}
+		if factor > 0 {
+			risk.Breakdown.PropertyFactor *= factor
+		}
+	}
+
+	b := risk.Breakdown
+	score := (b.Alerts + b.Secrets + b.EPSS + b.Coverage) * b.VisibilityFactor * b.PropertyFactor
+	risk.Score = math.Round(score*10) / 10
+	return risk
+}
+
+func repositoryVisibility(repo model.Repository) string {
+	switch {
+	case repo.Visibility != "":
+		return strings.ToLower(repo.Visibility)
+	case repo.Private:
+		return "private"
+	}
+	return "public"
+}
+
+// Risk prints the repositories of an organization ranked by risk score (the top ones when top > 0)
+func (r *RepositoryServices) Risk(org string, top int, jsonOutput bool) error {
+	weights, err := LoadRiskWeights()
+	if err != nil {
+		return err
+	}
+	risks, err := r.ScoreRepositories(org, weights)
+	if err != nil {
+		return err
+	}
+	if top > 0 && len(risks) > top {
+		risks = risks[:top]
+	}
+
+	if jsonOutput {
+		return jsonLister(risks)
+	}
+
+	tp, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+	tp.AddHeader([]string{"#", "Repository", "Score", "Critical", "High", "Medium", "Low", "Active Secrets", "Max EPSS", "Visibility", "Missing"})
+	for i, risk := range risks {
+		maxEPSS := "-"
+		if risk.MaxEPSS > 0 {
+			maxEPSS = fmt.Sprintf("%.0f%%", risk.MaxEPSS*100)
+		}
+		missing := strings.Join(risk.MissingFeatures, ",")
+		if missing == "" {
+			missing = "-"
+		}
+		tp.AddField(fmt.Sprintf("%d", i+1))
+		tp.AddField(risk.Repository)
+		tp.AddField(fmt.Sprintf("%.1f", risk.Score))
+		tp.AddField(fmt.Sprintf("%d", risk.Alerts["critical"]))
+		tp.AddField(fmt.Sprintf("%d", risk.Alerts["high"]))
+		tp.AddField(fmt.Sprintf("%d", risk.Alerts["medium"]))
+		tp.AddField(fmt.Sprintf("%d", risk.Alerts["low"]))
+		tp.AddField(fmt.Sprintf("%d", risk.ActiveSecrets))
+		tp.AddField(maxEPSS)
+		tp.AddField(risk.Visibility)
+		tp.AddField(missing)
+		tp.EndRow()
+	}
+	return tp.Render()
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+func TestScoreRepository(t *testing.T) {
+	weights := model.RiskWeights{
+		Severity:       map[string]float64{"critical": 10, "high": 5, "low": 1},
+		ActiveSecret:   20,
+		EPSS:           10,
+		MissingFeature: 3,
+		Visibility:     map[string]float64{"public": 2, "internal": 1.5},
+		Properties:     map[string]map[string]float64{"criticality": {"high": 2, "medium": 1.5}},
+	}
+	enabled := model.SecurityAndAnalysis{
+		SecretScanning:               model.Status{Status: "enabled"},
+		SecretScanningPushProtection: model.Status{Status: "enabled"},
+		DependabotSecurityUpdates:    model.Status{Status: "enabled"},
+	}
+	yes, no := true, false
+
+	tests := []struct {
+		name         string
+		repo         model.Repository
+		alerts       []model.SecurityAlert
+		codeScanning *bool
+		properties   map[string][]string
+		score        float64
+		missing      []string
+	}{
+		{
+			name:         "covered private repository without alerts",
+			repo:         model.Repository{Private: true, SecurityAndAnalysis: enabled},
+			codeScanning: &yes,
+			score:        0,
+			missing:      []string{},
+		},
+		{
+			name: "alerts by severity",
+			repo: model.Repository{Visibility: "private", SecurityAndAnalysis: enabled},
+			alerts: []model.SecurityAlert{
+				{Type: AlertTypeCodeScanning, Severity: "critical"},
+				{Type: AlertTypeCodeScanning, Severity: "high"},
+				{Type: AlertTypeCodeScanning, Severity: "unknown"},
+			},
+			score:   15,
+			missing: []string{},
+		},
+		{
+			name: "active secret and EPSS",
+			repo: model.Repository{Private: true, SecurityAndAnalysis: enabled},
+			alerts: []model.SecurityAlert{
+				{Type: AlertTypeSecretScanning, Severity: "high", Validity: "active"},
+				{Type: AlertTypeSecretScanning, Severity: "high", Validity: "inactive"},
+				{Type: AlertTypeDependabot, Severity: "low", EPSS: 0.25},
+			},
+			score:   5 + 20 + 5 + 1 + 2.5,
+			missing: []string{},
+		},
+		{
+			name:         "missing features on a public repository",
+			repo:         model.Repository{SecurityAndAnalysis: model.SecurityAndAnalysis{SecretScanning: model.Status{Status: "disabled"}, SecretScanningPushProtection: model.Status{Status: "disabled"}}},
+			codeScanning: &no,
+			score:        (3 * 3) * 2,
+			missing:      []string{CoverageCodeScanning, CoveragePushProtection, CoverageSecretScanning},
+		},
+		{
+			name:    "unknown coverage is not missing",
+			repo:    model.Repository{Visibility: "Internal"},
+			alerts:  []model.SecurityAlert{{Type: AlertTypeCodeScanning, Severity: "low"}},
+			score:   1.5,
+			missing: []string{},
+		},
+		{
+			name:       "property multipliers",
+			repo:       model.Repository{Private: true, SecurityAndAnalysis: enabled},
+			alerts:     []model.SecurityAlert{{Type: AlertTypeCodeScanning, Severity: "high"}},
+			properties: map[string][]string{"Criticality": {"Medium", "high"}, "team": {"payments"}},
+			score:      10,
+			missing:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risk := scoreRepository("org/repo", tt.repo, tt.alerts, tt.codeScanning, tt.properties, weights)
+			if risk.Score != tt.score {
+				t.Errorf("score = %v, want %v (breakdown %+v)", risk.Score, tt.score, risk.Breakdown)
+			}
+			if !slices.Equal(risk.MissingFeatures, tt.missing) {
+				t.Errorf("missing features = %v, want %v", risk.MissingFeatures, tt.missing)
+			}
+		})
+	}
+}
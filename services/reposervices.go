@@ -1,7 +1,9 @@
 package services
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/messagedigest-net/gh-advanced-security/model"
@@ -29,6 +31,39 @@ func (r *RepositoryServices) Get(name string) (*model.Repository, error) {
 	return repo, err
 }
 
+// escapePath escapes each segment of a file path for an URL, keeping the separators
+func escapePath(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// GetFileContent downloads a file from the repository at the given ref (branch, tag or commit SHA).
+// An empty ref uses the default branch.
+// Docs: GET /repos/{owner}/{repo}/contents/{path}
+func (r *RepositoryServices) GetFileContent(owner, repo, filePath, ref string) ([]byte, error) {
+	path := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, escapePath(strings.TrimPrefix(filePath, "/")))
+	if ref != "" {
+		path += "?ref=" + url.QueryEscape(ref)
+	}
+
+	content := &model.Content{}
+	if err := client.Get(path, content); err != nil {
+		return nil, err
+	}
+	if content.Type != "file" {
+		return nil, fmt.Errorf("%s is not a file", filePath)
+	}
+	if content.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported encoding '%s' for %s", content.Encoding, filePath)
+	}
+
+	// GitHub wraps the base64 payload in lines of 60 characters
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+}
+
 // FetchAllForOrg silently retrieves ALL repositories for automation (Bulk Enforcers).
 // It handles pagination automatically without user interaction.
 func (r *RepositoryServices) FetchAllForOrg(org string) ([]model.Repository, error) {
@@ -251,3 +286,31 @@ func (r *RepositoryServices) Show(name string, jsonOutput bool) error {
 
 	return tablePrinter.Render()
 }
+
+// GetCodeowners reads and parses the CODEOWNERS file of a repository (nil when there is none)
+func (r *RepositoryServices) GetCodeowners(owner, repo string) (Codeowners, error) {
+	for _, file := range CodeownersFiles {
+		content, err := r.GetFileContent(owner, repo, file, "")
+		if err == nil {
+			return ParseCodeowners(string(content)), nil
+		}
+		if !isNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// GetTeams lists the teams with access to a repository, with their permission
+// Docs: GET /repos/{owner}/{repo}/teams
+func (r *RepositoryServices) GetTeams(owner, repo string) ([]model.Team, error) {
+	return fetchAllPages[model.Team](fmt.Sprintf("repos/%s/%s/teams?per_page=100", owner, repo))
+}
+
+// GetCustomPropertyValues returns the custom property values of a repository
+// Docs: GET /repos/{owner}/{repo}/properties/values
+func (r *RepositoryServices) GetCustomPropertyValues(owner, repo string) ([]model.CustomPropertyValue, error) {
+	values := []model.CustomPropertyValue{}
+	err := client.Get(fmt.Sprintf("repos/%s/%s/properties/values", owner, repo), &values)
+	return values, err
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// SBOM export formats
+const (
+	SBOMFormatSPDX      = "spdx-json"
+	SBOMFormatCycloneDX = "cyclonedx-json"
+)
+
+var simpleLicenseID = regexp.MustCompile(`^[A-Za-z0-9.+-]+$`)
+
+// ConvertSPDXToCycloneDX maps an SPDX 2.3 document (as returned by the dependency graph) to CycloneDX 1.5.
+// The package described by the document becomes the metadata component, the other packages become
+// library components and DEPENDS_ON / DEPENDENCY_OF relationships become the dependency graph.
+func ConvertSPDXToCycloneDX(doc model.SPDXDocument) (model.CycloneDXBOM, error) {
+	serial, err := newUUID()
+	if err != nil {
+		return model.CycloneDXBOM{}, err
+	}
+
+	rootID := ""
+	if len(doc.DocumentDescribes) > 0 {
+		rootID = doc.DocumentDescribes[0]
+	}
+
+	dependsOn := map[string][]string{}
+	for _, r := range doc.Relationships {
+		switch r.RelationshipType {
+		case "DESCRIBES":
+			if rootID == "" {
+				rootID = r.RelatedSpdxElement
+			}
+		case "DEPENDS_ON":
+			dependsOn[r.SPDXElementID] = append(dependsOn[r.SPDXElementID], r.RelatedSpdxElement)
+		case "DEPENDENCY_OF":
+			dependsOn[r.RelatedSpdxElement] = append(dependsOn[r.RelatedSpdxElement], r.SPDXElementID)
+		}
+	}
+
+	bom := model.CycloneDXBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + serial,
+		Version:      1,
+		Metadata: model.CycloneDXMetadata{
+			Timestamp: doc.CreationInfo.Created,
+			Tools: model.CycloneDXTools{
+				Components: []model.CycloneDXComponent{{Type: "application", Name: "gh-advanced-security"}},
+			},
+		},
+		Components: []model.CycloneDXComponent{},
+	}
+
+	known := map[string]bool{}
+	for _, p := range doc.Packages {
+		component := spdxPackageToComponent(p)
+		known[p.SPDXID] = true
+
+		if p.SPDXID == rootID {
+			component.Type = "application"
+			bom.Metadata.Component = &component
+			continue
+		}
+		bom.Components = append(bom.Components, component)
+	}
+
+	for _, p := range doc.Packages {
+		refs := []string{}
+		for _, ref := range dependsOn[p.SPDXID] {
+			if known[ref] {
+				refs = append(refs, ref)
+			}
+		}
+		bom.Dependencies = append(bom.Dependencies, model.CycloneDXDependency{Ref: p.SPDXID, DependsOn: refs})
+	}
+
+	return bom, nil
+}
+
+func spdxPackageToComponent(p model.SPDXPackage) model.CycloneDXComponent {
+	component := model.CycloneDXComponent{
+		Type:    "library",
+		BOMRef:  p.SPDXID,
+		Name:    p.Name,
+		Version: p.VersionInfo,
+		Purl:    SPDXPackagePurl(p),
+	}
+
+	if component.Purl != "" {
+		_, namespace, name, _ := parsePurl(component.Purl)
+		component.Group = namespace
+		component.Name = name
+	} else if _, name, found := strings.Cut(p.Name, ":"); found {
+		// GitHub prefixes names with the ecosystem (e.g. "npm:lodash")
+		component.Name = name
+	}
+
+	if p.CopyrightText != "" && p.CopyrightText != "NOASSERTION" && p.CopyrightText != "NONE" {
+		component.Copyright = p.CopyrightText
+	}
+
+	license := SPDXPackageLicense(p)
+	switch {
+	case license == "":
+	case simpleLicenseID.MatchString(license):
+		// license.id only takes identifiers of the SPDX list, anything else (LicenseRef-...) is a name
+		if id, ok := spdxLicenseID(license); ok {
+			component.Licenses = []model.CycloneDXLicense{{License: &model.CycloneDXLicenseID{ID: id}}}
+		} else {
+			component.Licenses = []model.CycloneDXLicense{{License: &model.CycloneDXLicenseID{Name: license}}}
+		}
+	default:
+		component.Licenses = []model.CycloneDXLicense{{Expression: license}}
+	}
+
+	return component
+}
+
+// SPDXPackageLicense returns the concluded license, falling back to the declared one.
+// NOASSERTION / NONE are returned as empty.
+func SPDXPackageLicense(p model.SPDXPackage) string {
+	for _, l := range []string{p.LicenseConcluded, p.LicenseDeclared} {
+		if l != "" && l != "NOASSERTION" && l != "NONE" {
+			return l
+		}
+	}
+	return ""
+}
+
+// SPDXPackagePurl returns the package URL of an SPDX package, if any
+func SPDXPackagePurl(p model.SPDXPackage) string {
+	for _, ref := range p.ExternalRefs {
+		if ref.ReferenceType == "purl" {
+			return ref.ReferenceLocator
+		}
+	}
+	return ""
+}
+
+// parsePurl splits "pkg:type/namespace/name@version?qualifiers#subpath"
+func parsePurl(purl string) (kind, namespace, name, version string) {
+	rest := strings.TrimPrefix(purl, "pkg:")
+	rest, _, _ = strings.Cut(rest, "#")
+	rest, _, _ = strings.Cut(rest, "?")
+
+	if i := strings.LastIndex(rest, "@"); i > strings.LastIndex(rest, "/") {
+		version, _ = url.PathUnescape(rest[i+1:])
+		rest = rest[:i]
+	}
+
+	segments := strings.Split(rest, "/")
+	kind = strings.ToLower(segments[0])
+	if len(segments) > 1 {
+		name, _ = url.PathUnescape(segments[len(segments)-1])
+	}
+	if len(segments) > 2 {
+		namespace, _ = url.PathUnescape(strings.Join(segments[1:len(segments)-1], "/"))
+	}
+	return kind, namespace, name, version
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
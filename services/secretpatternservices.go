@@ -0,0 +1,358 @@
+package services
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"github.com/spf13/viper"
+)
+
+// Default delimiters used by GitHub when "Before secret" / "After secret" are left empty
+const (
+	DefaultBeforeSecret = `\A|[^0-9A-Za-z]`
+	DefaultAfterSecret  = `\z|[^0-9A-Za-z]`
+)
+
+var patternSvcs *SecretPatternServices
+
+type SecretPatternServices struct{}
+
+func GetSecretPatternServices() *SecretPatternServices {
+	if patternSvcs == nil {
+		patternSvcs = &SecretPatternServices{}
+	}
+	return patternSvcs
+}
+
+// ListCustomPatterns shows the custom patterns defined for an organization and their push protection setting
+// Docs: GET /orgs/{org}/secret-scanning/pattern-configurations
+func (s *SecretPatternServices) ListCustomPatterns(org string, jsonOutput bool) error {
+	configs := &model.PatternConfigurations{}
+	if err := client.Get(fmt.Sprintf("orgs/%s/secret-scanning/pattern-configurations", org), configs); err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return jsonLister(configs.CustomPatternOverrides)
+	}
+
+	tp, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	tp.AddHeader([]string{"Slug", "Name", "Token Type", "Push Protection", "Alerts", "False Positives", "Bypass Rate"})
+	for _, p := range configs.CustomPatternOverrides {
+		setting := p.Setting
+		if setting == "" || setting == "not-set" {
+			setting = p.DefaultSetting + " (default)"
+		}
+
+		tp.AddField(p.Slug)
+		tp.AddField(p.DisplayName)
+		tp.AddField(p.TokenType)
+		tp.AddField(setting)
+		tp.AddField(fmt.Sprintf("%d", p.AlertTotal))
+		tp.AddField(fmt.Sprintf("%d", p.FalsePositives))
+		tp.AddField(fmt.Sprintf("%.1f%%", p.BypassRate))
+		tp.EndRow()
+	}
+	return tp.Render()
+}
+
+// ListRepositoryCustomPatterns shows the custom patterns applying to a repository with the alerts they raised in it.
+// The API only lists the patterns of organizations: the other secret types found in its alerts that aren't
+// provider patterns are listed with an unknown source, as they may be repository or enterprise patterns.
+// Docs: GET /orgs/{org}/secret-scanning/pattern-configurations and GET /repos/{owner}/{repo}/secret-scanning/alerts
+func (s *SecretPatternServices) ListRepositoryCustomPatterns(owner, repo string, jsonOutput bool) error {
+	// Repositories of users have no organization patterns
+	configs := &model.PatternConfigurations{}
+	if err := client.Get(fmt.Sprintf("orgs/%s/secret-scanning/pattern-configurations", owner), configs); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	alerts, err := fetchAllPages[model.SecretScanningAlert](fmt.Sprintf("repos/%s/%s/secret-scanning/alerts?per_page=100", owner, repo))
+	if err != nil {
+		return err
+	}
+
+	providers := map[string]bool{}
+	for _, p := range configs.ProviderPatternOverrides {
+		providers[p.TokenType] = true
+	}
+	patterns := []*model.RepositoryCustomPattern{}
+	byType := map[string]*model.RepositoryCustomPattern{}
+	for _, p := range configs.CustomPatternOverrides {
+		setting := p.Setting
+		if setting == "" || setting == "not-set" {
+			setting = p.DefaultSetting + " (default)"
+		}
+		pattern := &model.RepositoryCustomPattern{Name: p.DisplayName, TokenType: p.TokenType, DefinedAt: "organization", PushProtection: setting}
+		patterns = append(patterns, pattern)
+		byType[p.TokenType] = pattern
+	}
+
+	for _, a := range alerts {
+		pattern, ok := byType[a.SecretType]
+		if !ok {
+			// Secret types in neither the provider nor the organization patterns can't be traced to where they
+			// are defined. Without the provider list (repositories of users) they can't be told apart from provider secrets.
+			if providers[a.SecretType] || len(providers) == 0 {
+				continue
+			}
+			pattern = &model.RepositoryCustomPattern{Name: a.SecretTypeDisplayName, TokenType: a.SecretType, DefinedAt: "unknown"}
+			patterns = append(patterns, pattern)
+			byType[a.SecretType] = pattern
+		}
+		pattern.TotalAlerts++
+		if a.State == "open" {
+			pattern.OpenAlerts++
+		}
+	}
+
+	if jsonOutput {
+		return jsonLister(patterns)
+	}
+
+	tp, err := getTablePrinter()
+	if err != nil {
+		return err
+	}
+
+	tp.AddHeader([]string{"Name", "Token Type", "Defined At", "Push Protection", "Open Alerts", "Total Alerts"})
+	for _, p := range patterns {
+		setting := p.PushProtection
+		if setting == "" {
+			setting = "-"
+		}
+		tp.AddField(p.Name)
+		tp.AddField(p.TokenType)
+		tp.AddField(p.DefinedAt)
+		tp.AddField(setting)
+		tp.AddField(fmt.Sprintf("%d", p.OpenAlerts))
+		tp.AddField(fmt.Sprintf("%d", p.TotalAlerts))
+		tp.EndRow()
+	}
+	return tp.Render()
+}
+
+// LoadPatterns reads custom pattern definitions from a YAML/JSON file with a top level "patterns" list
+func (s *SecretPatternServices) LoadPatterns(file string) ([]model.CustomPattern, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	var patterns []model.CustomPattern
+	if err := v.UnmarshalKey("patterns", &patterns); err != nil {
+		return nil, err
+	}
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no patterns found in %s", file)
+	}
+	return patterns, nil
+}
+
+// TestPatterns runs the patterns locally against the sample files (directories are walked)
+// and reports every match. Returns the number of matches found.
+func (s *SecretPatternServices) TestPatterns(patterns []model.CustomPattern, paths []string, jsonOutput bool) (int, error) {
+	var files []string
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if d.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			files = append(files, path)
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	compiled := make([]*compiledPattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		c, err := compilePattern(pattern)
+		if err != nil {
+			return 0, err
+		}
+		compiled = append(compiled, c)
+	}
+
+	// Each file is read once and every pattern runs on it
+	matches := []model.CustomPatternMatch{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return 0, err
+		}
+		for _, c := range compiled {
+			matches = append(matches, c.find(file, string(content))...)
+		}
+	}
+
+	if jsonOutput {
+		return len(matches), jsonLister(matches)
+	}
+
+	tp, err := getTablePrinter()
+	if err != nil {
+		return 0, err
+	}
+
+	tp.AddHeader([]string{"Pattern", "File", "Line", "Column", "Secret"})
+	for _, m := range matches {
+		tp.AddField(m.Pattern)
+		tp.AddField(m.Path)
+		tp.AddField(fmt.Sprintf("%d", m.Line))
+		tp.AddField(fmt.Sprintf("%d", m.Column))
+		tp.AddField(m.Secret)
+		tp.EndRow()
+	}
+	if err := tp.Render(); err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("%d match(es) in %d file(s) for %d pattern(s)\n", len(matches), len(files), len(patterns))
+	return len(matches), nil
+}
+
+// FindMatches applies a single pattern (with its before/after delimiters and
+// additional match requirements) to the content of one file.
+func (s *SecretPatternServices) FindMatches(pattern model.CustomPattern, path, content string) ([]model.CustomPatternMatch, error) {
+	c, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return c.find(path, content), nil
+}
+
+// compiledPattern is a CustomPattern ready to run on many files
+type compiledPattern struct {
+	name         string
+	re           *regexp.Regexp // before, secret and after, searched from the start of the content
+	resume       *regexp.Regexp // the same, searched from the character before a resume offset (see find)
+	secret       int            // index of the secret group
+	mustMatch    []*regexp.Regexp
+	mustNotMatch []*regexp.Regexp
+}
+
+func compilePattern(pattern model.CustomPattern) (*compiledPattern, error) {
+	before := pattern.BeforeSecret
+	if before == "" {
+		before = DefaultBeforeSecret
+	}
+	after := pattern.AfterSecret
+	if after == "" {
+		after = DefaultAfterSecret
+	}
+
+	expr := fmt.Sprintf("(?:%s)(?P<secret>%s)(?:%s)", before, pattern.SecretFormat, after)
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s': %w", pattern.Name, err)
+	}
+	// Skips the character before the resume offset, then the shortest text before a match
+	resume, err := regexp.Compile(`\A(?s:.)(?s:.*?)` + expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s': %w", pattern.Name, err)
+	}
+
+	c := &compiledPattern{name: pattern.Name, re: re, resume: resume, secret: re.SubexpIndex("secret")}
+	if c.mustMatch, err = compileAll(pattern.AdditionalMatch); err != nil {
+		return nil, fmt.Errorf("invalid additional match in '%s': %w", pattern.Name, err)
+	}
+	if c.mustNotMatch, err = compileAll(pattern.AdditionalNotMatch); err != nil {
+		return nil, fmt.Errorf("invalid additional not match in '%s': %w", pattern.Name, err)
+	}
+	return c, nil
+}
+
+// find reports the secrets of the content. The before and after delimiters are checks around the
+// secret: each search resumes at the end of the previous secret, not of its after delimiter, which
+// is often the before delimiter of the next secret ("a=SECRET1 b=SECRET2").
+// A resumed search starts at the character before the offset, so ^, \A and \b see the real
+// preceding text, and c.resume skips that character.
+func (c *compiledPattern) find(path, content string) []model.CustomPatternMatch {
+	matches := []model.CustomPatternMatch{}
+	for offset := 0; offset <= len(content); {
+		from, re := 0, c.re
+		if offset > 0 {
+			_, size := utf8.DecodeLastRuneInString(content[:offset])
+			from, re = offset-size, c.resume
+		}
+		loc := re.FindStringSubmatchIndex(content[from:])
+		if loc == nil {
+			break
+		}
+		start, end := from+loc[2*c.secret], from+loc[2*c.secret+1]
+
+		// Always move forward, even on an empty secret
+		next := end
+		if next <= offset {
+			_, size := utf8.DecodeRuneInString(content[offset:])
+			next = offset + max(size, 1)
+		}
+		offset = next
+
+		secret := content[start:end]
+		if secret == "" || !allMatch(c.mustMatch, secret) || anyMatch(c.mustNotMatch, secret) {
+			continue
+		}
+
+		line := strings.Count(content[:start], "\n") + 1
+		column := start - strings.LastIndex(content[:start], "\n")
+
+		matches = append(matches, model.CustomPatternMatch{
+			Pattern: c.name,
+			Path:    path,
+			Line:    line,
+			Column:  column,
+			Secret:  secret,
+		})
+	}
+	return matches
+}
+
+func compileAll(expressions []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, e := range expressions {
+		re, err := regexp.Compile(e)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+func allMatch(expressions []*regexp.Regexp, s string) bool {
+	for _, re := range expressions {
+		if !re.MatchString(s) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyMatch(expressions []*regexp.Regexp, s string) bool {
+	for _, re := range expressions {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+func TestFindMatches(t *testing.T) {
+	type match struct {
+		line, column int
+		secret       string
+	}
+	tests := []struct {
+		name    string
+		pattern model.CustomPattern
+		content string
+		want    []match
+	}{
+		{
+			name:    "default delimiters, several secrets per line",
+			pattern: model.CustomPattern{SecretFormat: `tok_[a-z]{4}`},
+			content: "a=tok_aaaa b=tok_bbbb\ntok_cccc",
+			want:    []match{{1, 3, "tok_aaaa"}, {1, 14, "tok_bbbb"}, {2, 1, "tok_cccc"}},
+		},
+		{
+			name:    "default delimiters, adjacent secrets",
+			pattern: model.CustomPattern{SecretFormat: `tok_[a-z]{4}`},
+			content: "tok_aaaa tok_bbbb",
+			want:    []match{{1, 1, "tok_aaaa"}, {1, 10, "tok_bbbb"}},
+		},
+		{
+			name:    "default delimiters, secret inside a word",
+			pattern: model.CustomPattern{SecretFormat: `tok_[a-z]{4}`},
+			content: "xtok_aaaa tok_bbbbx",
+			want:    []match{},
+		},
+		{
+			name:    "line start delimiter",
+			pattern: model.CustomPattern{SecretFormat: `tok_[a-z]{4}`, BeforeSecret: `(?m)^`, AfterSecret: `(?m)$`},
+			content: "tok_aaaa\ntok_bbbb\ntok_cccc",
+			want:    []match{{1, 1, "tok_aaaa"}, {2, 1, "tok_bbbb"}, {3, 1, "tok_cccc"}},
+		},
+		{
+			name:    "line start delimiter, not at a line start",
+			pattern: model.CustomPattern{SecretFormat: `tok_[a-z]{4}`, BeforeSecret: `(?m)^`, AfterSecret: `\s|\z`},
+			content: "tok_aaaa tok_bbbb\n tok_cccc\ntok_dddd",
+			want:    []match{{1, 1, "tok_aaaa"}, {3, 1, "tok_dddd"}},
+		},
+		{
+			name:    "text start delimiter",
+			pattern: model.CustomPattern{SecretFormat: `tok_[a-z]{4}`, BeforeSecret: `\A`, AfterSecret: `\s|\z`},
+			content: "tok_aaaa tok_bbbb\ntok_cccc",
+			want:    []match{{1, 1, "tok_aaaa"}},
+		},
+		{
+			name:    "word boundary delimiters",
+			pattern: model.CustomPattern{SecretFormat: `[a-z]{4}_[0-9]{4}`, BeforeSecret: `\b`, AfterSecret: `\b`},
+			content: "abcd_1234 efgh_5678\nxabcd_1234 ijkl_9012",
+			want:    []match{{1, 1, "abcd_1234"}, {1, 11, "efgh_5678"}, {2, 12, "ijkl_9012"}},
+		},
+		{
+			name:    "word boundary at the end of the previous secret",
+			pattern: model.CustomPattern{SecretFormat: `ab`, BeforeSecret: `\b`, AfterSecret: `.?`},
+			content: "abab ab",
+			want:    []match{{1, 1, "ab"}, {1, 6, "ab"}},
+		},
+		{
+			name:    "multi-byte character before a secret",
+			pattern: model.CustomPattern{SecretFormat: `tok_[a-z]{4}`},
+			content: "é=tok_aaaa tok_bbbb",
+			want:    []match{{1, 4, "tok_aaaa"}, {1, 14, "tok_bbbb"}},
+		},
+		{
+			name:    "additional match requirements",
+			pattern: model.CustomPattern{SecretFormat: `tok_[a-z]{4}`, AdditionalMatch: []string{`a`}, AdditionalNotMatch: []string{`^tok_b`}},
+			content: "tok_aaaa tok_baaa tok_cccc tok_caaa",
+			want:    []match{{1, 1, "tok_aaaa"}, {1, 28, "tok_caaa"}},
+		},
+	}
+
+	svc := GetSecretPatternServices()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.pattern.Name = "test"
+			found, err := svc.FindMatches(tt.pattern, "file.txt", tt.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(found) != len(tt.want) {
+				t.Fatalf("found %d matches %v, want %d", len(found), found, len(tt.want))
+			}
+			for i, w := range tt.want {
+				f := found[i]
+				if f.Line != w.line || f.Column != w.column || f.Secret != w.secret {
+					t.Errorf("match %d = %d:%d %q, want %d:%d %q", i, f.Line, f.Column, f.Secret, w.line, w.column, w.secret)
+				}
+			}
+		})
+	}
+}
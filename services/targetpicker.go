@@ -0,0 +1,319 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// maxRecentTargets is the number of targets remembered in the recent_targets key of the config file
+	maxRecentTargets = 10
+	// targetCacheTTL is how long the organizations and repositories offered by the picker are reused
+	targetCacheTTL = 12 * time.Hour
+)
+
+// TargetKind is what a command takes as target: the picker offers organizations, repositories or both.
+// Other targets (a file, a path...) are typed.
+type TargetKind int
+
+const (
+	TargetOrg TargetKind = 1 << iota
+	TargetRepo
+	TargetOrgOrRepo = TargetOrg | TargetRepo
+	TargetOther     = TargetKind(0)
+)
+
+// accepts tells whether a target has the form of the kind: owner/repo for repositories, a login for organizations
+func (k TargetKind) accepts(target string) bool {
+	owner, repo, found := strings.Cut(target, "/")
+	if strings.ContainsAny(target, " \t") || owner == "" {
+		return false
+	}
+	if found {
+		return k&TargetRepo != 0 && repo != "" && !strings.Contains(repo, "/")
+	}
+	return k&TargetOrg != 0
+}
+
+// errTypeTarget is returned by the picker when the user prefers to type the target
+var errTypeTarget = errors.New("type the target")
+
+// resolvedTarget is the target of the running command, remembered by RememberResolvedTarget when the command succeeds
+var resolvedTarget string
+
+// targetCache keeps the organizations of the user and the repositories of each organization between runs
+type targetCache struct {
+	Orgs  cachedNames            `json:"orgs"`
+	Repos map[string]cachedNames `json:"repos"`
+}
+
+type cachedNames struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Names     []string  `json:"names"`
+}
+
+func (c cachedNames) fresh() bool {
+	return len(c.Names) > 0 && time.Since(c.FetchedAt) < targetCacheTTL
+}
+
+// pickTarget offers a searchable list of the recent targets and of the organizations of the user
+// (then their repositories when the command takes one). Type to search, as in every selection prompt.
+// Returns errTypeTarget when the user chooses to type the target instead.
+func pickTarget(message string, kind TargetKind) (string, error) {
+	orgs, repos := kind&TargetOrg != 0, kind&TargetRepo != 0
+	GetPrompt()
+	cache := loadTargetCache()
+
+	const typeOption = "Type another name..."
+	options := []string{}
+	targets := []string{}
+	browse := map[int]string{} // options opening the repositories of an organization
+
+	for _, t := range RecentTargets() {
+		if strings.Contains(t, "/") && repos || !strings.Contains(t, "/") && orgs {
+			options = append(options, t)
+			targets = append(targets, t)
+		}
+	}
+
+	orgNames, err := cache.orgs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to list your organizations: %v\n", err)
+	}
+	for _, org := range orgNames {
+		if repos {
+			browse[len(options)] = org
+			options = append(options, org+"/…")
+			targets = append(targets, "")
+		} else if !slices.Contains(targets, org) {
+			options = append(options, org)
+			targets = append(targets, org)
+		}
+	}
+	options = append(options, typeOption)
+
+	choice, err := prompt.Select(message, "", options)
+	if err != nil {
+		return "", err
+	}
+	if options[choice] == typeOption {
+		return "", errTypeTarget
+	}
+	org, ok := browse[choice]
+	if !ok {
+		return targets[choice], nil
+	}
+
+	// Second step: the repositories of the organization, and the organization itself when it is a target
+	repoNames, err := cache.repos(org)
+	if err != nil {
+		return "", err
+	}
+	options = []string{}
+	if orgs {
+		options = append(options, org+" (whole organization)")
+	}
+	options = append(options, repoNames...)
+	options = append(options, typeOption)
+
+	choice, err = prompt.Select(message, "", options)
+	switch {
+	case err != nil:
+		return "", err
+	case options[choice] == typeOption:
+		return "", errTypeTarget
+	case orgs && choice == 0:
+		return org, nil
+	}
+	return options[choice], nil
+}
+
+// targetCacheFile returns where the picker caches the organizations and repositories
+func targetCacheFile() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gh-advanced-security", "targets.json")
+}
+
+// loadTargetCache reads the cache of the picker, an unreadable cache is just empty
+func loadTargetCache() *targetCache {
+	cache := &targetCache{}
+	if data, err := os.ReadFile(targetCacheFile()); err == nil {
+		json.Unmarshal(data, cache)
+	}
+	if cache.Repos == nil {
+		cache.Repos = map[string]cachedNames{}
+	}
+	return cache
+}
+
+func (c *targetCache) save() {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return
+	}
+	file := targetCacheFile()
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err == nil {
+		os.WriteFile(file, data, 0644)
+	}
+}
+
+// orgs returns the organizations of the user (and the default_org of the config file)
+// Docs: GET /user/orgs
+func (c *targetCache) orgs() ([]string, error) {
+	if !c.Orgs.fresh() {
+		found, err := fetchAllPages[model.Organization]("user/orgs?per_page=100")
+		if err != nil {
+			return defaultOrgs(), err
+		}
+		names := []string{}
+		for _, o := range found {
+			names = append(names, o.Login)
+		}
+		sort.Strings(names)
+		c.Orgs = cachedNames{FetchedAt: time.Now(), Names: names}
+		c.save()
+	}
+
+	names := defaultOrgs()
+	for _, n := range c.Orgs.Names {
+		if !slices.Contains(names, n) {
+			names = append(names, n)
+		}
+	}
+	return names, nil
+}
+
+func defaultOrgs() []string {
+	if org := GetDefaultOrg(); org != "" {
+		return []string{org}
+	}
+	return []string{}
+}
+
+// repos returns the (non archived) repositories of an organization as owner/repo
+func (c *targetCache) repos(org string) ([]string, error) {
+	if cached := c.Repos[org]; cached.fresh() {
+		return cached.Names, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Loading the repositories of %s...\n", org)
+	found, err := GetRepositoryServices().FetchAllForOrg(org)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, r := range found {
+		if !r.Archived {
+			names = append(names, org+"/"+r.Name)
+		}
+	}
+	sort.Strings(names)
+	c.Repos[org] = cachedNames{FetchedAt: time.Now(), Names: names}
+	c.save()
+	return names, nil
+}
+
+// RecentTargets returns the targets used last, most recent first (recent_targets in the config file)
+func RecentTargets() []string {
+	return viper.GetStringSlice("recent_targets")
+}
+
+// RememberResolvedTarget remembers the target of an interactive session once the command completed,
+// so targets that don't exist (or that the user can't access) don't end up in the picker
+func RememberResolvedTarget() error {
+	if resolvedTarget == "" {
+		return nil
+	}
+	target := resolvedTarget
+	resolvedTarget = ""
+	return RememberTarget(target)
+}
+
+// RememberTarget moves a target to the top of recent_targets, keeping maxRecentTargets
+func RememberTarget(target string) error {
+	recent := []string{target}
+	for _, t := range RecentTargets() {
+		if t != target && len(recent) < maxRecentTargets {
+			recent = append(recent, t)
+		}
+	}
+	if strings.Join(recent, ",") == strings.Join(RecentTargets(), ",") {
+		return nil
+	}
+	viper.Set("recent_targets", recent)
+	return saveConfigKey("recent_targets", recent)
+}
+
+// saveConfigKey writes a single key to the config file, keeping its other keys and comments
+// (viper.WriteConfig would also write the defaults and the flags of the run)
+func saveConfigKey(key string, value interface{}) error {
+	file := viper.ConfigFileUsed()
+	if file == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		file = filepath.Join(home, ".gh-advanced-security.yaml")
+	}
+
+	// The config file may hold notifier tokens: keep its permissions, and create it private
+	mode := os.FileMode(0600)
+	var doc yaml.Node
+	data, err := os.ReadFile(file)
+	switch {
+	case err == nil:
+		if info, err := os.Stat(file); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("invalid config file %s: %w", file, err)
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid config file %s: not a mapping", file)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return err
+	}
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content[i+1] = &node
+			replaced = true
+		}
+	}
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &node)
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	return os.WriteFile(file, out.Bytes(), mode)
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestTargetKindAccepts(t *testing.T) {
+	tests := []struct {
+		target string
+		kind   TargetKind
+		want   bool
+	}{
+		{"my-org", TargetOrg, true},
+		{"my-org", TargetRepo, false},
+		{"my-org", TargetOrgOrRepo, true},
+		{"owner/repo", TargetOrg, false},
+		{"owner/repo", TargetRepo, true},
+		{"owner/repo", TargetOrgOrRepo, true},
+		{"owner/", TargetRepo, false},
+		{"/repo", TargetRepo, false},
+		{"owner/repo/extra", TargetOrgOrRepo, false},
+		{"", TargetOrgOrRepo, false},
+		{"my org", TargetOrg, false},
+		{"owner/my repo", TargetRepo, false},
+		{"my-org", TargetOther, false},
+		{"owner/repo", TargetOther, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			if got := tt.kind.accepts(tt.target); got != tt.want {
+				t.Errorf("%v.accepts(%q) = %v, want %v", tt.kind, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveConfigKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string // no file when empty
+		mode     os.FileMode
+		want     []string // lines expected in the saved file
+		wantMode os.FileMode
+		wantErr  bool
+	}{
+		{
+			name:     "new file",
+			want:     []string{"recent_targets:", "  - my-org", "  - owner/repo"},
+			wantMode: 0600,
+		},
+		{
+			name:     "keeps other keys and comments",
+			existing: "# Notifiers\nnotifiers:\n  - name: slack # alerts channel\n    type: slack\n",
+			mode:     0640,
+			want:     []string{"# Notifiers", "  - name: slack # alerts channel", "recent_targets:", "  - my-org"},
+			wantMode: 0640,
+		},
+		{
+			name:     "replaces the key",
+			existing: "page_size: 30\nrecent_targets:\n  - old-org\n",
+			mode:     0600,
+			want:     []string{"page_size: 30", "recent_targets:", "  - owner/repo"},
+			wantMode: 0600,
+		},
+		{
+			name:     "not a mapping",
+			existing: "- a\n- b\n",
+			mode:     0600,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "config.yaml")
+			if tt.existing != "" {
+				if err := os.WriteFile(file, []byte(tt.existing), tt.mode); err != nil {
+					t.Fatal(err)
+				}
+			}
+			viper.SetConfigFile(file)
+			t.Cleanup(viper.Reset)
+
+			err := saveConfigKey("recent_targets", []string{"my-org", "owner/repo"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, line := range tt.want {
+				if !strings.Contains(string(data), line+"\n") {
+					t.Errorf("saved config misses %q:\n%s", line, data)
+				}
+			}
+			if strings.Contains(string(data), "old-org") {
+				t.Errorf("saved config kept the previous value:\n%s", data)
+			}
+			info, err := os.Stat(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != tt.wantMode {
+				t.Errorf("mode = %v, want %v", info.Mode().Perm(), tt.wantMode)
+			}
+		})
+	}
+}
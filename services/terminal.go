@@ -57,17 +57,26 @@ func GetPrompt() *prompter.Prompter {
 
 func getTablePrinter() (tableprinter.TablePrinter, error) {
 	if tablePrinter == nil {
-		t := GetTerminal()
-		w, _, err := t.Size()
+		tb, err := newTablePrinter()
 		if err != nil {
 			return nil, err
 		}
-		tb := tableprinter.New(t.Out(), t.IsTerminalOutput(), w)
 		tablePrinter = &tb
 	}
 	return *tablePrinter, nil
 }
 
+// newTablePrinter returns a printer for one more table of a command: the shared printer keeps the rows
+// it rendered, and every row of a table must have the number of fields of its first row
+func newTablePrinter() (tableprinter.TablePrinter, error) {
+	t := GetTerminal()
+	w, _, err := t.Size()
+	if err != nil {
+		return nil, err
+	}
+	return tableprinter.New(t.Out(), t.IsTerminalOutput(), w), nil
+}
+
 func enabledOrDisabled(b bool) string {
 	if b {
 		return "Enabled"
@@ -100,12 +109,31 @@ func ChooseSubCommand(subCmds []*cobra.Command, args []string, promptTitle strin
 	subCommands[choosen].Run(subCommands[choosen], args)
 }
 
-// GetTarget parses the target and all global flags.
+// GetTarget parses the target and all global flags. Without a target, interactive sessions pick it
+// (when kind is an organization and/or a repository) from the recent targets and the organizations and
+// repositories of the user, or type it.
 // Returns: target(string), flags(GlobalFlags)
-func GetTarget(cmd *cobra.Command, args []string, message string) (string, *GlobalFlags) {
+func GetTarget(cmd *cobra.Command, args []string, kind TargetKind, message string) (string, *GlobalFlags) {
 	var flags *GlobalFlags
 	var target string
 
+	interactive := term.IsTerminal(os.Stdin) && GetTerminal().IsTerminalOutput()
+
+	// With --user the target is a user, not an organization
+	if viper.GetBool("user") {
+		kind &^= TargetOrg
+	}
+
+	if len(args) < 1 && interactive && kind != TargetOther {
+		picked, err := pickTarget(message, kind)
+		if err == nil {
+			args = []string{picked}
+		} else if err != errTypeTarget {
+			fmt.Printf("Unable to read input: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	if len(args) < 1 {
 		GetPrompt()
 		response, err := prompt.Input(message, "")
@@ -132,6 +160,15 @@ func GetTarget(cmd *cobra.Command, args []string, message string) (string, *Glob
 	flags.All = viper.GetBool("all")
 	flags.PageSize = viper.GetInt("page")
 
+	if flags.User {
+		kind &^= TargetOrg // --user typed with the target
+	}
+	// Interactive sessions remember the target for the picker once the command completes
+	// (see RememberResolvedTarget; scripts leave the config file alone)
+	if interactive && kind.accepts(target) {
+		resolvedTarget = target
+	}
+
 	return target, flags
 }
 
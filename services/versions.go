@@ -0,0 +1,178 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// compareVersions compares two dotted versions (semver, maven, pep440-like).
+// Returns -1, 0 or 1. Pre-release suffixes ("-rc.1") sort before the release.
+func compareVersions(a, b string) int {
+	a = strings.TrimPrefix(strings.TrimSpace(a), "v")
+	b = strings.TrimPrefix(strings.TrimSpace(b), "v")
+	a, _, _ = strings.Cut(a, "+")
+	b, _, _ = strings.Cut(b, "+")
+
+	mainA, preA, _ := strings.Cut(a, "-")
+	mainB, preB, _ := strings.Cut(b, "-")
+
+	partsA := strings.Split(mainA, ".")
+	partsB := strings.Split(mainB, ".")
+	for i := 0; i < max(len(partsA), len(partsB)); i++ {
+		segA, segB := "0", "0"
+		if i < len(partsA) {
+			segA = partsA[i]
+		}
+		if i < len(partsB) {
+			segB = partsB[i]
+		}
+		if c := compareSegment(segA, segB); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return comparePrerelease(preA, preB)
+}
+
+// comparePrerelease follows the semver precedence of pre-releases: identifiers are compared one
+// by one, numerically when both are numbers, numbers sort before alphanumerics, and a shorter
+// list of identifiers sorts first when the others are equal ("alpha" < "alpha.1" < "alpha.beta").
+func comparePrerelease(a, b string) int {
+	idsA := strings.Split(a, ".")
+	idsB := strings.Split(b, ".")
+	for i := 0; i < min(len(idsA), len(idsB)); i++ {
+		numA, errA := strconv.ParseUint(idsA[i], 10, 64)
+		numB, errB := strconv.ParseUint(idsB[i], 10, 64)
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(idsA[i], idsB[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(idsA) < len(idsB):
+		return -1
+	case len(idsA) > len(idsB):
+		return 1
+	}
+	return 0
+}
+
+func compareSegment(a, b string) int {
+	numA, errA := strconv.Atoi(a)
+	numB, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// versionInRange evaluates a version against a range expression like "<2.17", ">=2.0.0, <2.17.1"
+// or "<1.2.3 || >=2.0 <2.5". Constraints separated by spaces/commas must all match,
+// alternatives separated by "||" are OR-ed. An empty range matches every version.
+func versionInRange(version, expression string) (bool, error) {
+	if strings.TrimSpace(expression) == "" {
+		return true, nil
+	}
+
+	for _, alternative := range strings.Split(expression, "||") {
+		constraints, err := parseConstraints(alternative)
+		if err != nil {
+			return false, err
+		}
+
+		matches := true
+		for _, c := range constraints {
+			if !c.matches(version) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type versionConstraint struct {
+	operator string
+	version  string
+}
+
+func (c versionConstraint) matches(version string) bool {
+	cmp := compareVersions(version, c.version)
+	switch c.operator {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "!=":
+		return cmp != 0
+	}
+	return cmp == 0
+}
+
+func parseConstraints(expression string) ([]versionConstraint, error) {
+	tokens := strings.Fields(strings.ReplaceAll(expression, ",", " "))
+
+	var constraints []versionConstraint
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		operator := ""
+		for _, op := range []string{">=", "<=", "!=", "==", ">", "<", "="} {
+			if strings.HasPrefix(token, op) {
+				operator = op
+				token = strings.TrimPrefix(token, op)
+				break
+			}
+		}
+
+		// Operator separated from the version by a space (">= 2.0")
+		if token == "" {
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("invalid version range '%s'", expression)
+			}
+			i++
+			token = tokens[i]
+		}
+
+		if operator == "==" || operator == "" {
+			operator = "="
+		}
+		constraints = append(constraints, versionConstraint{operator: operator, version: token})
+	}
+	return constraints, nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.2.3", "1.10.0", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.0.0+build.5", "1.0.0+build.7", 0},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0", "1.0.0-rc.1", 1},
+		// semver precedence example: 1.0.0-alpha < alpha.1 < alpha.beta < beta < beta.2 < beta.11 < rc.1 < 1.0.0
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"1.0.0-beta", "1.0.0-beta.2", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-rc.10", "1.0.0-rc.9", 1},
+		{"1.0.0-1", "1.0.0-alpha", -1},
+		{"1.0.0-alpha.10", "1.0.0-alpha.10", 0},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestVersionInRange(t *testing.T) {
+	tests := []struct {
+		version, expression string
+		want                bool
+	}{
+		{"1.5.0", "", true},
+		{"2.16.0", "<2.17", true},
+		{"2.17.1", ">=2.0.0, <2.17.1", false},
+		{"2.17.1-rc.2", ">=2.0.0, <2.17.1", true},
+		{"2.17.1-rc.10", "<2.17.1-rc.9", false},
+		{"1.0.0", "<1.2.3 || >=2.0 <2.5", true},
+		{"2.3.0", "<1.2.3 || >=2.0 <2.5", true},
+		{"1.5.0", "<1.2.3 || >=2.0 <2.5", false},
+		{"2.0.0", ">= 2.0", true},
+		{"2.0.0", "= 2.0.0", true},
+		{"2.0.1", "!=2.0.1", false},
+	}
+	for _, tt := range tests {
+		got, err := versionInRange(tt.version, tt.expression)
+		if err != nil {
+			t.Errorf("versionInRange(%q, %q) failed: %v", tt.version, tt.expression, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("versionInRange(%q, %q) = %t, want %t", tt.version, tt.expression, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+// maxWebhookPayload is the largest payload GitHub delivers (25 MB)
+const maxWebhookPayload = 25 << 20
+
+// webhookAlertTypes maps the security alert webhook events to the alert types
+var webhookAlertTypes = map[string]string{
+	"code_scanning_alert":   AlertTypeCodeScanning,
+	"secret_scanning_alert": AlertTypeSecretScanning,
+	"dependabot_alert":      AlertTypeDependabot,
+}
+
+// webhookAlertEvents maps the actions of the security alert webhook events to alert events.
+// Other actions (assigned, validated, appeared_in_branch...) are not changes of state.
+var webhookAlertEvents = map[string]string{
+	"created":          AlertEventNew,
+	"reopened":         AlertEventReopened,
+	"reopened_by_user": AlertEventReopened,
+	"auto_reopened":    AlertEventReopened,
+	"reintroduced":     AlertEventReopened,
+	"fixed":            AlertEventResolved,
+	"closed_by_user":   AlertEventResolved,
+	"resolved":         AlertEventResolved,
+	"dismissed":        AlertEventResolved,
+	"auto_dismissed":   AlertEventResolved,
+}
+
+// WebhookOptions configures the webhook receiver
+type WebhookOptions struct {
+	Listen    string
+	Path      string
+	Secret    string
+	Enable    []string // features enabled on created and transferred repositories
+	Notifiers []string // names from the config file, no notification when empty
+}
+
+// webhookHandler validates and dispatches webhook deliveries. The work is done after answering,
+// as GitHub expects a response within 10 seconds.
+type webhookHandler struct {
+	secret     []byte
+	enable     []string
+	notifiers  []Notifier
+	jsonOutput bool
+	output     sync.Mutex
+	pending    sync.WaitGroup
+}
+
+// NewWebhookHandler returns the http.Handler receiving the GitHub webhooks
+func NewWebhookHandler(opts WebhookOptions, jsonOutput bool) (http.Handler, error) {
+	return newWebhookHandler(opts, jsonOutput)
+}
+
+func newWebhookHandler(opts WebhookOptions, jsonOutput bool) (*webhookHandler, error) {
+	if opts.Secret == "" {
+		return nil, errors.New("a webhook secret is required: unsigned deliveries can't be trusted")
+	}
+	if err := ValidateFeatures(opts.Enable); err != nil {
+		return nil, err
+	}
+
+	h := &webhookHandler{secret: []byte(opts.Secret), jsonOutput: jsonOutput}
+	// Keep the order of RepositoryFeatures: push protection needs secret scanning first
+	for _, f := range RepositoryFeatures {
+		if slices.Contains(opts.Enable, f) {
+			h.enable = append(h.enable, f)
+		}
+	}
+	if len(opts.Notifiers) > 0 {
+		var err error
+		if h.notifiers, err = LoadNotifiers(opts.Notifiers); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// ServeWebhooks receives webhooks on opts.Listen until ctx is cancelled
+func ServeWebhooks(ctx context.Context, opts WebhookOptions, jsonOutput bool) error {
+	handler, err := newWebhookHandler(opts, jsonOutput)
+	if err != nil {
+		return err
+	}
+
+	path := opts.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	server := &http.Server{Addr: opts.Listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		server.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Receiving webhooks on %s%s. Press Ctrl+C to stop.\n", opts.Listen, path)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	// Let the deliveries already accepted finish
+	handler.pending.Wait()
+	return nil
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookPayload))
+	if err != nil {
+		http.Error(w, "unreadable payload", http.StatusBadRequest)
+		return
+	}
+	if !validSignature(h.secret, body, r.Header.Get("X-Hub-Signature-256")) {
+		h.logf("rejected delivery %s: invalid signature", r.Header.Get("X-GitHub-Delivery"))
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event := r.Header.Get("X-GitHub-Event")
+	if event == "ping" {
+		fmt.Fprintln(w, "pong")
+		return
+	}
+
+	payload := model.WebhookPayload{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	var work func()
+	switch {
+	case event == "repository":
+		work = func() { h.handleRepository(payload) }
+	case webhookAlertTypes[event] != "":
+		work = func() { h.handleAlert(webhookAlertTypes[event], payload) }
+	default:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	h.pending.Add(1)
+	go func() {
+		defer h.pending.Done()
+		work()
+	}()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// validSignature checks the HMAC-SHA256 of the payload ("sha256=<hex>") in constant time
+func validSignature(secret, body []byte, signature string) bool {
+	digest, found := strings.CutPrefix(signature, "sha256=")
+	if !found {
+		return false
+	}
+	received, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(received, mac.Sum(nil))
+}
+
+// handleRepository enables the configured features on new repositories, including the ones
+// transferred from another organization, which the organization defaults for new repositories skip
+func (h *webhookHandler) handleRepository(payload model.WebhookPayload) {
+	if payload.Action != "created" && payload.Action != "transferred" {
+		return
+	}
+	repository := payload.Repository.FullName
+	if len(h.enable) == 0 || payload.Repository.Archived {
+		h.logf("repository %s %s", repository, payload.Action)
+		return
+	}
+
+	owner, repo, _ := strings.Cut(repository, "/")
+	svc := GetEnforcerServices()
+	for _, feature := range h.enable {
+		if err := svc.SetRepositoryFeature(owner, repo, feature, true); err != nil {
+			h.logf("repository %s %s: enabling %s failed: %v", repository, payload.Action, feature, err)
+			continue
+		}
+		h.logf("repository %s %s: %s enabled", repository, payload.Action, feature)
+	}
+}
+
+// handleAlert reports the changes of state of an alert and sends them to the notifiers
+func (h *webhookHandler) handleAlert(alertType string, payload model.WebhookPayload) {
+	event := webhookAlertEvents[payload.Action]
+	if event == "" {
+		return
+	}
+	alerts, err := decodeSecurityAlerts(payload.Repository.FullName, alertType, []json.RawMessage{payload.Alert})
+	if err != nil || len(alerts) == 0 {
+		h.logf("invalid %s payload: %v", alertType, err)
+		return
+	}
+
+	events := []model.AlertEvent{{Event: event, Alert: alerts[0], DetectedAt: time.Now().UTC().Format(time.RFC3339)}}
+	h.output.Lock()
+	if h.jsonOutput {
+		json.NewEncoder(os.Stdout).Encode(events[0])
+	} else {
+		e := events[0]
+		fmt.Printf("%s %-8s %s %s #%d [%s] %s\n", time.Now().Format(time.DateTime), e.Event, e.Alert.Repository, e.Alert.Type, e.Alert.Number, e.Alert.Severity, e.Alert.Title)
+	}
+	h.output.Unlock()
+
+	if len(h.notifiers) > 0 {
+		if err := Notify(h.notifiers, events); err != nil {
+			h.logf("notification failed: %v", err)
+		}
+	}
+}
+
+// logf writes a status line to stderr, keeping stdout for the alert events
+func (h *webhookHandler) logf(format string, args ...interface{}) {
+	h.output.Lock()
+	defer h.output.Unlock()
+	fmt.Fprintf(os.Stderr, "%s %s\n", time.Now().Format(time.DateTime), fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/messagedigest-net/gh-advanced-security/model"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidSignature(t *testing.T) {
+	body := `{"action":"created"}`
+
+	tests := []struct {
+		name      string
+		signature string
+		want      bool
+	}{
+		{"valid", sign("secret", body), true},
+		{"other secret", sign("other", body), false},
+		{"other body", sign("secret", body+" "), false},
+		{"missing", "", false},
+		{"sha1", "sha1=" + strings.TrimPrefix(sign("secret", body), "sha256="), false},
+		{"not hex", "sha256=not-hex", false},
+		{"truncated", sign("secret", body)[:20], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validSignature([]byte("secret"), []byte(body), tt.signature); got != tt.want {
+				t.Errorf("validSignature(%q) = %v, want %v", tt.signature, got, tt.want)
+			}
+		})
+	}
+}
+
+// recordingNotifier records the events it receives
+type recordingNotifier struct {
+	events []model.AlertEvent
+}
+
+func (r *recordingNotifier) Name() string {
+	return "recorder"
+}
+
+func (r *recordingNotifier) Notify(events []model.AlertEvent) error {
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func TestWebhookAlertEvents(t *testing.T) {
+	tests := []struct {
+		event     string
+		action    string
+		alertType string
+		want      string // empty when nothing is notified
+	}{
+		{"code_scanning_alert", "created", AlertTypeCodeScanning, AlertEventNew},
+		{"code_scanning_alert", "reopened_by_user", AlertTypeCodeScanning, AlertEventReopened},
+		{"code_scanning_alert", "fixed", AlertTypeCodeScanning, AlertEventResolved},
+		{"code_scanning_alert", "closed_by_user", AlertTypeCodeScanning, AlertEventResolved},
+		{"code_scanning_alert", "appeared_in_branch", AlertTypeCodeScanning, ""},
+		{"secret_scanning_alert", "created", AlertTypeSecretScanning, AlertEventNew},
+		{"secret_scanning_alert", "resolved", AlertTypeSecretScanning, AlertEventResolved},
+		{"secret_scanning_alert", "reopened", AlertTypeSecretScanning, AlertEventReopened},
+		{"secret_scanning_alert", "validated", AlertTypeSecretScanning, ""},
+		{"dependabot_alert", "auto_dismissed", AlertTypeDependabot, AlertEventResolved},
+		{"dependabot_alert", "auto_reopened", AlertTypeDependabot, AlertEventReopened},
+		{"dependabot_alert", "reintroduced", AlertTypeDependabot, AlertEventReopened},
+		{"dependabot_alert", "assignees_changed", AlertTypeDependabot, ""},
+		{"issues", "opened", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.event+" "+tt.action, func(t *testing.T) {
+			recorder := &recordingNotifier{}
+			h := &webhookHandler{secret: []byte("secret"), notifiers: []Notifier{recorder}, jsonOutput: true}
+
+			body := `{"action":"` + tt.action + `","alert":{"number":7,"state":"open"},"repository":{"full_name":"org/api"}}`
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set("X-GitHub-Event", tt.event)
+			req.Header.Set("X-Hub-Signature-256", sign("secret", body))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			h.pending.Wait()
+
+			if tt.alertType == "" {
+				if rec.Code != http.StatusNoContent {
+					t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+				}
+			} else if rec.Code != http.StatusAccepted {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusAccepted)
+			}
+
+			switch {
+			case tt.want == "" && len(recorder.events) > 0:
+				t.Errorf("notified %v, want nothing", recorder.events)
+			case tt.want != "" && len(recorder.events) != 1:
+				t.Errorf("notified %d events, want 1", len(recorder.events))
+			case tt.want != "":
+				e := recorder.events[0]
+				if e.Event != tt.want || e.Alert.Type != tt.alertType || e.Alert.Repository != "org/api" || e.Alert.Number != 7 {
+					t.Errorf("notified %s %s, want %s %s:%s:7", e.Event, AlertKey(e.Alert), tt.want, "org/api", tt.alertType)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookRejectedDeliveries(t *testing.T) {
+	h := &webhookHandler{secret: []byte("secret")}
+	body := `{"zen":"Keep it logically awesome."}`
+
+	tests := []struct {
+		name      string
+		method    string
+		event     string
+		signature string
+		want      int
+	}{
+		{"ping", http.MethodPost, "ping", sign("secret", body), http.StatusOK},
+		{"unsigned", http.MethodPost, "ping", "", http.StatusUnauthorized},
+		{"wrong secret", http.MethodPost, "ping", sign("other", body), http.StatusUnauthorized},
+		{"not a post", http.MethodGet, "ping", sign("secret", body), http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", strings.NewReader(body))
+			req.Header.Set("X-GitHub-Event", tt.event)
+			req.Header.Set("X-Hub-Signature-256", tt.signature)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}